@@ -0,0 +1,123 @@
+package stablecoin
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// AuditHasher hashes audit payloads with a per-deployment salt, so two
+// deployments never produce identical hashes for identical inputs and
+// records cannot be correlated across deployments.
+type AuditHasher struct {
+	salt []byte
+}
+
+func NewAuditHasher(salt []byte) *AuditHasher {
+	return &AuditHasher{salt: salt}
+}
+
+// Hash returns the salted SHA-256 of the payload, hex encoded.
+func (h *AuditHasher) Hash(payload []byte) string {
+	sum := sha256.New()
+	sum.Write(h.salt)
+	sum.Write([]byte{0})
+	sum.Write(payload)
+	return hex.EncodeToString(sum.Sum(nil))
+}
+
+// LoadOrCreateAuditSalt reads the deployment's audit salt from path,
+// generating and persisting a fresh 32-byte salt on first run.
+func LoadOrCreateAuditSalt(path string) ([]byte, error) {
+	salt, err := os.ReadFile(path)
+	if err == nil && len(salt) > 0 {
+		return salt, nil
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	salt = make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, salt, 0o600); err != nil {
+		return nil, err
+	}
+	return salt, nil
+}
+
+// AuditRecord is one entry in the compliance audit trail.
+type AuditRecord struct {
+	SchemaVersion int                    `json:"schema_version"`
+	Timestamp     time.Time              `json:"timestamp"`
+	Kind          string                 `json:"kind"`
+	Jurisdiction  string                 `json:"jurisdiction,omitempty"`
+	Details       map[string]interface{} `json:"details"`
+}
+
+// AuditSink persists audit records. Implementations must be safe for
+// concurrent use.
+type AuditSink interface {
+	WriteAudit(rec AuditRecord) error
+}
+
+// AuditRouter writes each record to the sink configured for its
+// jurisdiction, so data-residency rules are honored, with a default sink
+// for everything else.
+type AuditRouter struct {
+	mu       sync.RWMutex
+	routes   map[string]AuditSink
+	fallback AuditSink
+}
+
+func NewAuditRouter(fallback AuditSink) *AuditRouter {
+	return &AuditRouter{
+		routes:   make(map[string]AuditSink),
+		fallback: fallback,
+	}
+}
+
+// Route stores records for a jurisdiction in the given sink.
+func (r *AuditRouter) Route(jurisdiction string, sink AuditSink) {
+	r.mu.Lock()
+	r.routes[jurisdiction] = sink
+	r.mu.Unlock()
+}
+
+func (r *AuditRouter) WriteAudit(rec AuditRecord) error {
+	r.mu.RLock()
+	sink, ok := r.routes[rec.Jurisdiction]
+	if !ok {
+		sink = r.fallback
+	}
+	r.mu.RUnlock()
+	if sink == nil {
+		return nil
+	}
+	return sink.WriteAudit(rec)
+}
+
+// FileAuditSink appends audit records as JSONL.
+type FileAuditSink struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileAuditSink(path string) *FileAuditSink {
+	return &FileAuditSink{path: path}
+}
+
+func (s *FileAuditSink) WriteAudit(rec AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewEncoder(f).Encode(rec)
+}
@@ -0,0 +1,60 @@
+package stablecoin
+
+import (
+	"fmt"
+	"sync"
+)
+
+// FanoutAuditSink writes every record to several sinks independently: one
+// sink failing never prevents the others from being attempted. Each sink is
+// registered as required or best-effort; only a required sink's failure
+// fails the operation, while best-effort failures are isolated and metered.
+type FanoutAuditSink struct {
+	metrics *Metrics
+
+	mu    sync.RWMutex
+	sinks []fanoutAuditEntry
+}
+
+type fanoutAuditEntry struct {
+	name     string
+	sink     AuditSink
+	required bool
+}
+
+func NewFanoutAuditSink(metrics *Metrics) *FanoutAuditSink {
+	if metrics == nil {
+		metrics = DefaultMetrics
+	}
+	return &FanoutAuditSink{metrics: metrics}
+}
+
+// Add registers a named sink. Required sinks propagate their write failures
+// to the caller; best-effort sinks only count them.
+func (f *FanoutAuditSink) Add(name string, sink AuditSink, required bool) {
+	f.mu.Lock()
+	f.sinks = append(f.sinks, fanoutAuditEntry{name: name, sink: sink, required: required})
+	f.mu.Unlock()
+}
+
+func (f *FanoutAuditSink) WriteAudit(rec AuditRecord) error {
+	f.mu.RLock()
+	sinks := make([]fanoutAuditEntry, len(f.sinks))
+	copy(sinks, f.sinks)
+	f.mu.RUnlock()
+
+	var firstRequiredErr error
+	for _, entry := range sinks {
+		err := entry.sink.WriteAudit(rec)
+		if err == nil {
+			continue
+		}
+		f.metrics.Counter("audit_sink_failures_total", map[string]string{
+			"sink": entry.name,
+		}).Inc()
+		if entry.required && firstRequiredErr == nil {
+			firstRequiredErr = fmt.Errorf("required audit sink %s: %v", entry.name, err)
+		}
+	}
+	return firstRequiredErr
+}
@@ -0,0 +1,197 @@
+package stablecoin
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAuditHasherSaltsPerDeployment(t *testing.T) {
+	payload := []byte(`{"kind":"enforcement_decision"}`)
+
+	a := NewAuditHasher([]byte("deployment-a"))
+	b := NewAuditHasher([]byte("deployment-b"))
+	if a.Hash(payload) == b.Hash(payload) {
+		t.Fatal("different salts produced the same hash: records are correlatable across deployments")
+	}
+	if a.Hash(payload) != a.Hash(payload) {
+		t.Fatal("same salt and payload produced unstable hashes")
+	}
+
+	// The persisted salt survives restarts and is created exactly once.
+	path := filepath.Join(t.TempDir(), "audit.salt")
+	first, err := LoadOrCreateAuditSalt(path)
+	if err != nil || len(first) != 32 {
+		t.Fatalf("LoadOrCreateAuditSalt first run: %d bytes, %v", len(first), err)
+	}
+	second, err := LoadOrCreateAuditSalt(path)
+	if err != nil || !bytes.Equal(first, second) {
+		t.Fatalf("salt changed across restarts: %x vs %x, %v", first, second, err)
+	}
+}
+
+func TestAuditRouterHonorsResidency(t *testing.T) {
+	de := &memAuditSink{}
+	sg := &memAuditSink{}
+	fallback := &memAuditSink{}
+	router := NewAuditRouter(fallback)
+	router.Route("DE", de)
+	router.Route("SG", sg)
+
+	for _, j := range []string{"DE", "SG", "DE", "US"} {
+		if err := router.WriteAudit(AuditRecord{Kind: "enforcement_decision", Jurisdiction: j}); err != nil {
+			t.Fatalf("WriteAudit(%s): %v", j, err)
+		}
+	}
+	if len(de.records) != 2 || len(sg.records) != 1 || len(fallback.records) != 1 {
+		t.Fatalf("routed DE=%d SG=%d fallback=%d, want 2/1/1",
+			len(de.records), len(sg.records), len(fallback.records))
+	}
+	if fallback.records[0].Jurisdiction != "US" {
+		t.Fatalf("fallback received %+v, want the unrouted US record", fallback.records[0])
+	}
+}
+
+func TestRecordSchemaVersioning(t *testing.T) {
+	// Current-version roundtrip keeps the stamp.
+	rec, err := DecodeAuditRecord([]byte(`{"schema_version":1,"kind":"bypass"}`))
+	if err != nil || rec.SchemaVersion != 1 || rec.Kind != "bypass" {
+		t.Fatalf("v1 roundtrip: %+v, %v", rec, err)
+	}
+	// Pre-versioning records default to version 1.
+	rec, err = DecodeAuditRecord([]byte(`{"kind":"bypass"}`))
+	if err != nil || rec.SchemaVersion != 1 {
+		t.Fatalf("missing schema_version: %+v, %v", rec, err)
+	}
+	// Additive future fields are ignored, not fatal.
+	rec, err = DecodeAuditRecord([]byte(`{"schema_version":1,"kind":"bypass","future_field":"x"}`))
+	if err != nil || rec.Kind != "bypass" {
+		t.Fatalf("record with unknown field: %+v, %v", rec, err)
+	}
+
+	dec, err := DecodeDecisionRecord([]byte(`{"transaction_id":"tx-1","allowed":true,"future_field":42}`))
+	if err != nil || dec.SchemaVersion != 1 || dec.TransactionID != "tx-1" || !dec.Allowed {
+		t.Fatalf("decision record with unknown field: %+v, %v", dec, err)
+	}
+}
+
+func TestEncryptedAuditSinkRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.enc")
+	key := bytes.Repeat([]byte{7}, 32)
+	sink, err := NewEncryptedFileAuditSink(path, key)
+	if err != nil {
+		t.Fatalf("NewEncryptedFileAuditSink: %v", err)
+	}
+
+	now := time.Now().UTC()
+	rec := AuditRecord{
+		SchemaVersion: AuditSchemaVersion,
+		Timestamp:     now,
+		Kind:          "enforcement_decision",
+		Details:       map[string]interface{}{"transaction_id": "tx-secret-1"},
+	}
+	if err := sink.WriteAudit(rec); err != nil {
+		t.Fatalf("WriteAudit: %v", err)
+	}
+
+	got, err := sink.ReadAudits(now.Add(-time.Minute), now.Add(time.Minute))
+	if err != nil || len(got) != 1 || got[0].Details["transaction_id"] != "tx-secret-1" {
+		t.Fatalf("ReadAudits = %+v, %v; want the stored record back", got, err)
+	}
+
+	// Nothing recognizable reaches the disk in cleartext.
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading sink file: %v", err)
+	}
+	for _, leak := range []string{"tx-secret-1", "enforcement_decision", "transaction_id"} {
+		if bytes.Contains(raw, []byte(leak)) {
+			t.Fatalf("cleartext %q found in the encrypted audit file", leak)
+		}
+	}
+
+	// A different key cannot open the trail.
+	wrong, err := NewEncryptedFileAuditSink(path, bytes.Repeat([]byte{8}, 32))
+	if err != nil {
+		t.Fatalf("NewEncryptedFileAuditSink: %v", err)
+	}
+	if _, err := wrong.ReadAudits(now.Add(-time.Minute), now.Add(time.Minute)); err == nil {
+		t.Fatal("wrong key decrypted the audit trail")
+	}
+}
+
+func TestFanoutAuditSinkIsolatesFailures(t *testing.T) {
+	rec := AuditRecord{Kind: "enforcement_decision"}
+
+	// A best-effort sink failing is metered but does not fail the write.
+	metrics := NewMetrics()
+	fanout := NewFanoutAuditSink(metrics)
+	healthy := &memAuditSink{}
+	fanout.Add("primary", healthy, true)
+	fanout.Add("mirror", &memAuditSink{err: errors.New("disk full")}, false)
+	if err := fanout.WriteAudit(rec); err != nil {
+		t.Fatalf("best-effort sink failure propagated: %v", err)
+	}
+	if len(healthy.records) != 1 {
+		t.Fatalf("healthy sink received %d records, want 1", len(healthy.records))
+	}
+	if got := metrics.Counter("audit_sink_failures_total", map[string]string{"sink": "mirror"}).Value(); got != 1 {
+		t.Fatalf("failure counter for mirror = %d, want 1", got)
+	}
+
+	// A required sink failing fails the write, but the others still run.
+	strict := NewFanoutAuditSink(NewMetrics())
+	survivor := &memAuditSink{}
+	strict.Add("primary", &memAuditSink{err: errors.New("disk full")}, true)
+	strict.Add("mirror", survivor, false)
+	if err := strict.WriteAudit(rec); err == nil {
+		t.Fatal("required sink failure did not propagate")
+	}
+	if len(survivor.records) != 1 {
+		t.Fatalf("later sink received %d records after a required failure, want 1", len(survivor.records))
+	}
+}
+
+func TestGenerateComplianceReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	sink := NewFileAuditSink(path)
+	decisions := NewAuditDecisionSink(sink)
+
+	now := time.Now().UTC()
+	seed := []DecisionRecord{
+		{TransactionID: "tx-1", Allowed: true, Reason: "passed", Timestamp: now},
+		{TransactionID: "tx-2", Allowed: false, Reason: "kyc level too low", Timestamp: now},
+		{TransactionID: "tx-3", Allowed: false, Reason: "kyc level too low", Timestamp: now},
+		{TransactionID: "tx-4", Allowed: false, Reason: "geo blocked", Timestamp: now},
+	}
+	for _, rec := range seed {
+		if err := decisions.Publish(rec); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+	// Records outside the window and unrelated kinds are not aggregated.
+	sink.WriteAudit(AuditRecord{Kind: "enforcement_decision", Timestamp: now.Add(-48 * time.Hour),
+		Details: map[string]interface{}{"allowed": false, "reason": "stale"}})
+	sink.WriteAudit(AuditRecord{Kind: "bypass", Timestamp: now, Details: map[string]interface{}{}})
+
+	hasher := NewAuditHasher([]byte("salt"))
+	report, err := GenerateComplianceReport(sink, hasher, now.Add(-time.Hour), now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GenerateComplianceReport: %v", err)
+	}
+	if report.TotalEnforced != 4 {
+		t.Fatalf("TotalEnforced = %d, want 4", report.TotalEnforced)
+	}
+	if report.RejectedByReason["kyc level too low"] != 2 || report.RejectedByReason["geo blocked"] != 1 {
+		t.Fatalf("RejectedByReason = %v", report.RejectedByReason)
+	}
+	if report.RejectedByReason["stale"] != 0 {
+		t.Fatal("record outside the window was aggregated")
+	}
+	if report.SignedSummaryHash != hasher.Hash([]byte(report.summary())) {
+		t.Fatal("summary hash does not verify against the deployment salt")
+	}
+}
@@ -0,0 +1,178 @@
+package stablecoin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrModelUnavailable is returned in strict mode when the model cannot
+// answer and degrading to keyword heuristics is not acceptable.
+var ErrModelUnavailable = errors.New("classification model unavailable")
+
+// DefaultContaminationWeights is the historical all-or-nothing keyword list,
+// expressed as weights that each cross the default threshold on their own.
+var DefaultContaminationWeights = map[string]float64{
+	"exchange": 1.0,
+	"bursa":    1.0,
+	"external": 1.0,
+}
+
+// ContaminationChecker decides whether a transaction touches a disallowed
+// venue. A model does the primary classification; a weighted keyword scan is
+// the fallback when no model is available or the model fails.
+type ContaminationChecker struct {
+	mu        sync.RWMutex
+	model     Model
+	weights   map[string]float64
+	threshold float64
+	normalize bool
+	strict    bool
+
+	reviewBand     float64 // half-width of the manual-review dead-band
+	reviewNotifier *WebhookNotifier
+
+	interpret *OutputInterpreter // nil means the default probability-at-0.5
+
+	bypass *InternalAllowList // nil means no identity bypasses the check
+
+	predCache *predictionCache // nil disables output memoization
+
+	// Per-origin model routing (see contamination_origin.go).
+	originLoader ModelLoader
+	originPaths  map[string]string
+	originLoaded map[string]Model
+}
+
+func NewContaminationChecker(model Model) *ContaminationChecker {
+	return &ContaminationChecker{
+		model:     model,
+		weights:   DefaultContaminationWeights,
+		threshold: 1.0,
+		normalize: true,
+	}
+}
+
+// SetModel swaps the classification model. A nil model drops the checker to
+// keyword heuristics.
+func (cc *ContaminationChecker) SetModel(m Model) {
+	cc.mu.Lock()
+	cc.model = m
+	// Cached outputs belong to the outgoing model.
+	if cc.predCache != nil {
+		cc.predCache.clear()
+	}
+	cc.mu.Unlock()
+}
+
+// ActiveModel reports which classifier is in use: the model's name, or
+// "heuristic-fallback" when no model is loaded.
+func (cc *ContaminationChecker) ActiveModel() string {
+	cc.mu.RLock()
+	defer cc.mu.RUnlock()
+	if cc.model != nil {
+		return cc.model.Name()
+	}
+	return "heuristic-fallback"
+}
+
+// SetNormalization toggles input normalization (trim, NFC, case-fold)
+// before classification. Enabled by default.
+func (cc *ContaminationChecker) SetNormalization(on bool) {
+	cc.mu.Lock()
+	cc.normalize = on
+	cc.mu.Unlock()
+}
+
+// SetFallbackWeights configures the keyword weights and the score threshold
+// at or above which the fallback reports contamination.
+func (cc *ContaminationChecker) SetFallbackWeights(weights map[string]float64, threshold float64) {
+	cc.mu.Lock()
+	cc.weights = weights
+	cc.threshold = threshold
+	cc.mu.Unlock()
+}
+
+// fallbackScore sums the weights of every configured term found in the
+// transaction's fields.
+func (cc *ContaminationChecker) fallbackScore(tx Transaction) float64 {
+	cc.mu.RLock()
+	defer cc.mu.RUnlock()
+	if cc.normalize {
+		tx = normalizeTransaction(tx)
+	}
+	haystack := strings.ToLower(strings.Join([]string{
+		tx.ID, tx.Origin, tx.Recipient, tx.Currency,
+	}, " "))
+	var score float64
+	for term, weight := range cc.weights {
+		if strings.Contains(haystack, strings.ToLower(term)) {
+			score += weight
+		}
+	}
+	return score
+}
+
+// fallbackContaminated reports whether the weighted keyword score crosses
+// the threshold.
+func (cc *ContaminationChecker) fallbackContaminated(tx Transaction) bool {
+	cc.mu.RLock()
+	threshold := cc.threshold
+	cc.mu.RUnlock()
+	return cc.fallbackScore(tx) >= threshold
+}
+
+// SetStrictMode disables heuristic fallbacks: with strict mode on, any
+// model failure surfaces as ErrModelUnavailable instead of silently
+// degrading to keyword matching.
+func (cc *ContaminationChecker) SetStrictMode(on bool) {
+	cc.mu.Lock()
+	cc.strict = on
+	cc.mu.Unlock()
+}
+
+// IsContaminated classifies the transaction, preferring the model and using
+// the weighted keyword fallback when the model is absent or errors (unless
+// strict mode is on, in which case the failure is returned).
+func (cc *ContaminationChecker) IsContaminated(tx Transaction) (bool, error) {
+	if cc.bypassed(tx) {
+		return false, nil
+	}
+	model := cc.modelFor(tx.Origin)
+	cc.mu.RLock()
+	strict := cc.strict
+	cc.mu.RUnlock()
+
+	if model != nil {
+		raw, err := cc.predict(model, tx)
+		if err == nil {
+			if cc.outputInterpreter().Contaminated(raw) {
+				logRejectedFeatures(tx)
+				return true, nil
+			}
+			return false, nil
+		}
+		if strict {
+			return false, fmt.Errorf("%w: %v", ErrModelUnavailable, err)
+		}
+	} else if strict {
+		return false, ErrModelUnavailable
+	}
+
+	contaminated := cc.fallbackContaminated(tx)
+	if contaminated {
+		logRejectedFeatures(tx)
+	}
+	return contaminated, nil
+}
+
+// featureVector is the numeric encoding handed to classification models.
+func featureVector(tx Transaction) []float64 {
+	return []float64{
+		tx.Amount,
+		float64(len(tx.Origin)),
+		float64(len(tx.Recipient)),
+		float64(tx.Timestamp),
+	}
+}
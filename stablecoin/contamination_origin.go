@@ -0,0 +1,49 @@
+package stablecoin
+
+// Per-origin model selection: different origin classes (mining payouts,
+// p2p transfers) carry different risk profiles and can be assigned their
+// own detection models, with the checker's main model as the default.
+
+// SetOriginModelLoader installs the loader used to lazily materialize
+// per-origin models on first use.
+func (cc *ContaminationChecker) SetOriginModelLoader(load ModelLoader) {
+	cc.mu.Lock()
+	cc.originLoader = load
+	cc.mu.Unlock()
+}
+
+// SetOriginModel routes transactions from origin through the model at path,
+// loaded lazily and cached. Re-assigning evicts the cached model.
+func (cc *ContaminationChecker) SetOriginModel(origin, path string) {
+	cc.mu.Lock()
+	if cc.originPaths == nil {
+		cc.originPaths = make(map[string]string)
+		cc.originLoaded = make(map[string]Model)
+	}
+	cc.originPaths[origin] = path
+	delete(cc.originLoaded, origin)
+	cc.mu.Unlock()
+}
+
+// modelFor picks the model for one transaction's origin, falling back to
+// the default model when no assignment exists or the assigned model fails
+// to load (the failure is logged, not fatal: classification must go on).
+func (cc *ContaminationChecker) modelFor(origin string) Model {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if m, ok := cc.originLoaded[origin]; ok {
+		return m
+	}
+	path, ok := cc.originPaths[origin]
+	if !ok || cc.originLoader == nil {
+		return cc.model
+	}
+	m, err := cc.originLoader(path)
+	if err != nil {
+		debugf("loading origin model for %s from %s: %v; using default", origin, path, err)
+		return cc.model
+	}
+	cc.originLoaded[origin] = m
+	return m
+}
@@ -0,0 +1,76 @@
+package stablecoin
+
+import "fmt"
+
+// Verdict is the three-way outcome of a classification: besides clean and
+// contaminated, an ambiguous score can be routed to manual review.
+type Verdict int
+
+const (
+	VerdictClean Verdict = iota
+	VerdictContaminated
+	VerdictReview
+)
+
+// ReasonReview marks decisions deferred to a human reviewer.
+const ReasonReview = "routed to manual review"
+
+// SetReviewBand configures a dead-band of the given half-width around the
+// model threshold: scores within it are neither auto-accepted nor
+// auto-rejected but routed to manual review through the notifier. A zero
+// width disables the band.
+func (cc *ContaminationChecker) SetReviewBand(width float64, notifier *WebhookNotifier) {
+	cc.mu.Lock()
+	cc.reviewBand = width
+	cc.reviewNotifier = notifier
+	cc.mu.Unlock()
+}
+
+// Classify runs the model and maps its score to a verdict. Scores inside
+// the review band around the threshold yield VerdictReview and are emitted
+// to the review notifier; scores clearly on either side auto-decide as
+// IsContaminated does. Fallback and strict-mode behavior match
+// IsContaminated.
+func (cc *ContaminationChecker) Classify(tx Transaction) (Verdict, error) {
+	if cc.bypassed(tx) {
+		return VerdictClean, nil
+	}
+	model := cc.modelFor(tx.Origin)
+	cc.mu.RLock()
+	strict := cc.strict
+	band := cc.reviewBand
+	notifier := cc.reviewNotifier
+	cc.mu.RUnlock()
+
+	if model != nil {
+		raw, err := cc.predict(model, tx)
+		if err == nil {
+			oi := cc.outputInterpreter()
+			score := oi.Score(raw)
+			if band > 0 && score > oi.Threshold-band && score < oi.Threshold+band {
+				if notifier != nil {
+					notifier.Enqueue([]byte(fmt.Sprintf(
+						`{"event":"manual_review","id":%q,"origin":%q,"score":%g}`,
+						tx.ID, tx.Origin, score)))
+				}
+				return VerdictReview, nil
+			}
+			if oi.Contaminated(raw) {
+				logRejectedFeatures(tx)
+				return VerdictContaminated, nil
+			}
+			return VerdictClean, nil
+		}
+		if strict {
+			return VerdictClean, fmt.Errorf("%w: %v", ErrModelUnavailable, err)
+		}
+	} else if strict {
+		return VerdictClean, ErrModelUnavailable
+	}
+
+	if cc.fallbackContaminated(tx) {
+		logRejectedFeatures(tx)
+		return VerdictContaminated, nil
+	}
+	return VerdictClean, nil
+}
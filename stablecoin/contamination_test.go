@@ -0,0 +1,315 @@
+package stablecoin
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// modelStub returns a fixed score (or error) and counts inferences.
+type modelStub struct {
+	name  string
+	score float64
+	err   error
+	calls int32
+}
+
+func (m *modelStub) Name() string { return m.name }
+
+func (m *modelStub) Predict(features []float64) (float64, error) {
+	atomic.AddInt32(&m.calls, 1)
+	return m.score, m.err
+}
+
+// memAuditSink collects audit records in memory.
+type memAuditSink struct {
+	records []AuditRecord
+	err     error
+}
+
+func (s *memAuditSink) WriteAudit(rec AuditRecord) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.records = append(s.records, rec)
+	return nil
+}
+
+func TestFallbackWeightsSumAcrossTerms(t *testing.T) {
+	cc := NewContaminationChecker(nil)
+	cc.SetFallbackWeights(map[string]float64{"exchange": 0.4, "external": 0.4}, 0.7)
+
+	oneTerm := Transaction{ID: "tx-1", Origin: "exchange-account", Recipient: "bob"}
+	contaminated, err := cc.IsContaminated(oneTerm)
+	if err != nil || contaminated {
+		t.Fatalf("single low-weight term: %v, %v; want clean", contaminated, err)
+	}
+
+	bothTerms := Transaction{ID: "tx-2", Origin: "exchange-account", Recipient: "external-wallet"}
+	contaminated, err = cc.IsContaminated(bothTerms)
+	if err != nil || !contaminated {
+		t.Fatalf("summed terms crossing threshold: %v, %v; want contaminated", contaminated, err)
+	}
+}
+
+func TestStrictModeDisablesFallback(t *testing.T) {
+	failing := &modelStub{name: "broken", err: errors.New("session lost")}
+	cc := NewContaminationChecker(failing)
+
+	// Without strict mode the keyword heuristic decides.
+	contaminated, err := cc.IsContaminated(Transaction{ID: "tx-1", Origin: "exchange"})
+	if err != nil || !contaminated {
+		t.Fatalf("fallback result: %v, %v; want heuristic contamination", contaminated, err)
+	}
+
+	cc.SetStrictMode(true)
+	if _, err := cc.IsContaminated(Transaction{ID: "tx-1", Origin: "exchange"}); !errors.Is(err, ErrModelUnavailable) {
+		t.Fatalf("strict mode with failing model: %v, want ErrModelUnavailable", err)
+	}
+
+	noModel := NewContaminationChecker(nil)
+	noModel.SetStrictMode(true)
+	if _, err := noModel.IsContaminated(Transaction{ID: "tx-1", Origin: "exchange"}); !errors.Is(err, ErrModelUnavailable) {
+		t.Fatalf("strict mode without model: %v, want ErrModelUnavailable", err)
+	}
+}
+
+func TestNonFiniteModelOutputIsAnInferenceError(t *testing.T) {
+	for _, score := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if _, err := predictChecked(&modelStub{name: "unstable", score: score}, []float64{1}); !errors.Is(err, ErrInvalidModelOutput) {
+			t.Errorf("output %g: %v, want ErrInvalidModelOutput", score, err)
+		}
+	}
+
+	// The error follows the normal fallback policy: heuristics without
+	// strict mode, ErrModelUnavailable with it.
+	cc := NewContaminationChecker(&modelStub{name: "unstable", score: math.NaN()})
+	contaminated, err := cc.IsContaminated(Transaction{ID: "tx-1", Origin: "exchange"})
+	if err != nil || !contaminated {
+		t.Fatalf("NaN output fallback: %v, %v; want heuristic contamination", contaminated, err)
+	}
+	cc.SetStrictMode(true)
+	if _, err := cc.IsContaminated(Transaction{ID: "tx-1", Origin: "exchange"}); !errors.Is(err, ErrModelUnavailable) {
+		t.Fatalf("NaN output under strict mode: %v, want ErrModelUnavailable", err)
+	}
+}
+
+func TestInstrumentedModelInputLimit(t *testing.T) {
+	inner := &modelStub{name: "m", score: 0.1}
+	im := InstrumentModel(inner, nil)
+	im.SetMaxInputLength(4)
+
+	if _, err := im.Predict(make([]float64, 4)); err != nil {
+		t.Fatalf("at-limit input rejected: %v", err)
+	}
+	if _, err := im.Predict(make([]float64, 5)); !errors.Is(err, ErrInputTooLarge) {
+		t.Fatalf("over-limit input: %v, want ErrInputTooLarge", err)
+	}
+	// The oversized input never reached the model.
+	if got := atomic.LoadInt32(&inner.calls); got != 1 {
+		t.Fatalf("model saw %d inferences, want 1", got)
+	}
+}
+
+func TestOutputInterpreterVariants(t *testing.T) {
+	cases := []struct {
+		name string
+		oi   OutputInterpreter
+		raw  float64
+		want bool
+	}{
+		{"probability above", OutputInterpreter{Threshold: 0.5}, 0.9, true},
+		{"probability below", OutputInterpreter{Threshold: 0.5}, 0.2, false},
+		{"logit positive", OutputInterpreter{Threshold: 0.5, ApplySigmoid: true}, 3.0, true},
+		{"logit negative", OutputInterpreter{Threshold: 0.5, ApplySigmoid: true}, -3.0, false},
+		{"inverted low", OutputInterpreter{Threshold: 0.5, Inverted: true}, 0.1, true},
+		{"inverted high", OutputInterpreter{Threshold: 0.5, Inverted: true}, 0.9, false},
+	}
+	for _, tc := range cases {
+		if got := tc.oi.Contaminated(tc.raw); got != tc.want {
+			t.Errorf("%s: Contaminated(%g) = %v, want %v", tc.name, tc.raw, got, tc.want)
+		}
+	}
+
+	// Wired into the checker: an inverted-label model flips the verdict.
+	cc := NewContaminationChecker(&modelStub{name: "inverted", score: 0.1})
+	cc.SetOutputInterpreter(OutputInterpreter{Threshold: 0.5, Inverted: true})
+	contaminated, err := cc.IsContaminated(Transaction{ID: "tx-1", Origin: "alice"})
+	if err != nil || !contaminated {
+		t.Fatalf("inverted model score 0.1: %v, %v; want contaminated", contaminated, err)
+	}
+}
+
+func TestReviewBandRoutesAmbiguousScores(t *testing.T) {
+	model := &modelStub{name: "m", score: 0.9}
+	cc := NewContaminationChecker(model)
+	notifier := NewWebhookNotifier("http://unused.invalid", 8, DefaultRetryPolicy())
+	cc.SetReviewBand(0.1, notifier)
+
+	verdict, err := cc.Classify(Transaction{ID: "tx-1", Origin: "alice"})
+	if err != nil || verdict != VerdictContaminated {
+		t.Fatalf("score clearly above: %v, %v; want contaminated", verdict, err)
+	}
+
+	model.score = 0.1
+	verdict, err = cc.Classify(Transaction{ID: "tx-2", Origin: "alice"})
+	if err != nil || verdict != VerdictClean {
+		t.Fatalf("score clearly below: %v, %v; want clean", verdict, err)
+	}
+
+	model.score = 0.52
+	verdict, err = cc.Classify(Transaction{ID: "tx-3", Origin: "alice"})
+	if err != nil || verdict != VerdictReview {
+		t.Fatalf("score inside the dead-band: %v, %v; want review", verdict, err)
+	}
+	notifier.mu.Lock()
+	queued := len(notifier.queue)
+	payload := ""
+	if queued > 0 {
+		payload = string(notifier.queue[0])
+	}
+	notifier.mu.Unlock()
+	if queued != 1 || !strings.Contains(payload, "manual_review") || !strings.Contains(payload, "tx-3") {
+		t.Fatalf("review notification = %d queued, %q; want one manual_review event for tx-3", queued, payload)
+	}
+}
+
+func TestBypassListSkipsContaminationAndAudits(t *testing.T) {
+	audit := &memAuditSink{}
+	al := NewInternalAllowList(audit)
+	al.Allow("issuance-engine", CheckContamination)
+
+	// The model would flag everything; the bypass wins for the internal
+	// identity only.
+	cc := NewContaminationChecker(&modelStub{name: "m", score: 0.99})
+	cc.SetBypassList(al)
+
+	contaminated, err := cc.IsContaminated(Transaction{ID: "tx-1", Origin: "issuance-engine"})
+	if err != nil || contaminated {
+		t.Fatalf("allow-listed identity: %v, %v; want clean", contaminated, err)
+	}
+	if len(audit.records) != 1 || audit.records[0].Kind != "bypass" {
+		t.Fatalf("audit records = %+v; want one bypass record", audit.records)
+	}
+	if audit.records[0].Details["identity"] != "issuance-engine" || audit.records[0].Details["check"] != CheckContamination {
+		t.Fatalf("bypass record details = %+v", audit.records[0].Details)
+	}
+
+	contaminated, err = cc.IsContaminated(Transaction{ID: "tx-2", Origin: "alice"})
+	if err != nil || !contaminated {
+		t.Fatalf("normal identity: %v, %v; want contaminated", contaminated, err)
+	}
+	// The bypass not taken is not audited.
+	if len(audit.records) != 1 {
+		t.Fatalf("audit records grew to %d, want 1", len(audit.records))
+	}
+}
+
+func TestPerOriginModelRouting(t *testing.T) {
+	models := map[string]*modelStub{
+		"models/mining.pb": {name: "mining", score: 0.9},
+		"models/p2p.pb":    {name: "p2p", score: 0.1},
+	}
+	cc := NewContaminationChecker(&modelStub{name: "default", score: 0.1})
+	cc.SetOriginModelLoader(func(path string) (Model, error) {
+		m, ok := models[path]
+		if !ok {
+			return nil, fmt.Errorf("no model at %s", path)
+		}
+		return m, nil
+	})
+	cc.SetOriginModel("mining", "models/mining.pb")
+	cc.SetOriginModel("p2p", "models/p2p.pb")
+
+	// Identical transactions, different origins, different verdicts.
+	contaminated, err := cc.IsContaminated(Transaction{ID: "tx-1", Origin: "mining", Amount: 5})
+	if err != nil || !contaminated {
+		t.Fatalf("mining origin: %v, %v; want contaminated", contaminated, err)
+	}
+	contaminated, err = cc.IsContaminated(Transaction{ID: "tx-1", Origin: "p2p", Amount: 5})
+	if err != nil || contaminated {
+		t.Fatalf("p2p origin: %v, %v; want clean", contaminated, err)
+	}
+
+	// An assignment whose model fails to load falls back to the default.
+	cc.SetOriginModel("rewards", "models/missing.pb")
+	contaminated, err = cc.IsContaminated(Transaction{ID: "tx-1", Origin: "rewards", Amount: 5})
+	if err != nil || contaminated {
+		t.Fatalf("failed origin load: %v, %v; want default model's clean verdict", contaminated, err)
+	}
+}
+
+func TestPredictionCacheSkipsRepeatInference(t *testing.T) {
+	model := &modelStub{name: "m", score: 0.9}
+	cc := NewContaminationChecker(model)
+	cc.SetPredictionCache(16, time.Minute)
+
+	tx := Transaction{ID: "tx-1", Origin: "alice", Amount: 5, Timestamp: 1700000000}
+	for i := 0; i < 3; i++ {
+		if _, err := cc.IsContaminated(tx); err != nil {
+			t.Fatalf("IsContaminated: %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&model.calls); got != 1 {
+		t.Fatalf("model ran %d inferences for identical features, want 1", got)
+	}
+
+	// Reloading the model invalidates cached outputs.
+	fresh := &modelStub{name: "m2", score: 0.9}
+	cc.SetModel(fresh)
+	if _, err := cc.IsContaminated(tx); err != nil {
+		t.Fatalf("IsContaminated after reload: %v", err)
+	}
+	if got := atomic.LoadInt32(&fresh.calls); got != 1 {
+		t.Fatalf("reloaded model ran %d inferences, want 1 (cache cleared)", got)
+	}
+}
+
+func TestTenantModelRegistry(t *testing.T) {
+	models := map[string]Model{
+		"models/acme.pb":   &modelStub{name: "acme", score: 0.9},
+		"models/globex.pb": &modelStub{name: "globex", score: 0.1},
+	}
+	shared := &modelStub{name: "shared", score: 0.1}
+	tr := NewTenantModelRegistry(func(path string) (Model, error) {
+		m, ok := models[path]
+		if !ok {
+			return nil, fmt.Errorf("no model at %s", path)
+		}
+		return m, nil
+	}, shared)
+	tr.Assign("acme", "models/acme.pb")
+	tr.Assign("globex", "models/globex.pb")
+
+	tx := Transaction{ID: "tx-1", Origin: "alice", Amount: 5}
+
+	acme, err := tr.CheckerFor("acme")
+	if err != nil {
+		t.Fatalf("CheckerFor(acme): %v", err)
+	}
+	if contaminated, err := acme.IsContaminated(tx); err != nil || !contaminated {
+		t.Fatalf("acme verdict: %v, %v; want contaminated", contaminated, err)
+	}
+
+	globex, err := tr.CheckerFor("globex")
+	if err != nil {
+		t.Fatalf("CheckerFor(globex): %v", err)
+	}
+	if contaminated, err := globex.IsContaminated(tx); err != nil || contaminated {
+		t.Fatalf("globex verdict: %v, %v; want clean", contaminated, err)
+	}
+
+	// Unassigned tenants share the default model.
+	if m, err := tr.ModelFor("startup"); err != nil || m.Name() != "shared" {
+		t.Fatalf("unassigned tenant got %v, %v; want shared model", m, err)
+	}
+	// A bad assignment surfaces instead of silently falling back.
+	tr.Assign("broken", "models/missing.pb")
+	if _, err := tr.ModelFor("broken"); err == nil {
+		t.Fatal("load failure for assigned tenant did not surface")
+	}
+}
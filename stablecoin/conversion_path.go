@@ -0,0 +1,146 @@
+package stablecoin
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNoConversionPath is returned when no allowed sequence of conversions
+// connects the requested currencies within the hop limit.
+var ErrNoConversionPath = errors.New("no conversion path to target currency")
+
+// ConversionHop is one leg of a multi-hop conversion.
+type ConversionHop struct {
+	From      string
+	To        string
+	Rate      float64
+	Fee       float64
+	AmountOut float64
+}
+
+// SetPairRate allows direct conversion between two currencies at the given
+// rate. Pairs are directional.
+func (c *Converter) SetPairRate(from, to string, rate float64) {
+	c.mu.Lock()
+	if c.pairs == nil {
+		c.pairs = make(map[string]map[string]float64)
+	}
+	if c.pairs[from] == nil {
+		c.pairs[from] = make(map[string]float64)
+	}
+	c.pairs[from][to] = rate
+	c.mu.Unlock()
+}
+
+// SetMaxHops caps the length of indirect conversion paths. Default is 3.
+func (c *Converter) SetMaxHops(n int) {
+	c.mu.Lock()
+	c.maxHops = n
+	c.mu.Unlock()
+}
+
+// findPath returns the shortest allowed currency path from -> to, or nil.
+// Callers hold c.mu.
+func (c *Converter) findPath(from, to string) []string {
+	maxHops := c.maxHops
+	if maxHops <= 0 {
+		maxHops = 3
+	}
+	type node struct {
+		currency string
+		path     []string
+	}
+	visited := map[string]bool{from: true}
+	queue := []node{{currency: from, path: []string{from}}}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		if len(n.path)-1 >= maxHops {
+			continue
+		}
+		for next := range c.pairs[n.currency] {
+			if visited[next] {
+				continue
+			}
+			path := append(append([]string{}, n.path...), next)
+			if next == to {
+				return path
+			}
+			visited[next] = true
+			queue = append(queue, node{currency: next, path: path})
+		}
+	}
+	return nil
+}
+
+// ConvertPath converts amount of the from currency into the to currency,
+// composing allowed pair conversions when no direct pair exists. The
+// per-hop fee is deducted on every leg and the full path is recorded in the
+// journal receipt.
+func (c *Converter) ConvertPath(origin, from, to string, amount float64) (float64, []ConversionHop, error) {
+	if err := checkMaintenance(); err != nil {
+		return 0, nil, err
+	}
+	if origin == "" {
+		return 0, nil, fmt.Errorf("origin is required")
+	}
+	if amount <= 0 {
+		return 0, nil, fmt.Errorf("amount must be positive, got %g", amount)
+	}
+	// The final hop is what leaves the system; a fiat target is a fiat
+	// off-ramp regardless of how many stablecoin legs precede it.
+	if err := c.checkTarget(to); err != nil {
+		c.recordFailure()
+		return 0, nil, err
+	}
+	// A multi-hop conversion is still one conversion of amount Pi from
+	// this origin; it draws on the same rolling quota as the direct route.
+	if err := c.checkQuota(origin, amount); err != nil {
+		c.recordFailure()
+		return 0, nil, err
+	}
+
+	c.mu.RLock()
+	path := c.findPath(from, to)
+	fee := c.fee
+	rates := make([]float64, 0)
+	for i := 0; i+1 < len(path); i++ {
+		rates = append(rates, c.pairs[path[i]][path[i+1]])
+	}
+	c.mu.RUnlock()
+
+	if path == nil {
+		return 0, nil, fmt.Errorf("%w: %s -> %s", ErrNoConversionPath, from, to)
+	}
+
+	hops := make([]ConversionHop, 0, len(rates))
+	current := amount
+	for i, rate := range rates {
+		current = current*rate - fee
+		hops = append(hops, ConversionHop{
+			From:      path[i],
+			To:        path[i+1],
+			Rate:      rate,
+			Fee:       fee,
+			AmountOut: current,
+		})
+	}
+	if err := c.checkLimits(to, current); err != nil {
+		c.recordFailure()
+		return 0, nil, err
+	}
+
+	now := time.Now()
+	rec := ConversionRecord{
+		Origin:      origin,
+		Currency:    to,
+		AmountIn:    amount,
+		AmountOut:   current,
+		Path:        hops,
+		ReceiptHash: receiptHash(origin, amount, current, now),
+		Timestamp:   now,
+	}
+	c.appendJournal(rec)
+	return current, hops, nil
+}
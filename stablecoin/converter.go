@@ -0,0 +1,178 @@
+package stablecoin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrConversionAmountRange is returned when a conversion amount falls
+// outside the configured per-currency limits.
+var ErrConversionAmountRange = errors.New("conversion amount out of range")
+
+// ConversionRecord is the converter's journal entry for one executed
+// conversion.
+type ConversionRecord struct {
+	// Offset is the record's position in the append-only journal,
+	// assigned monotonically at append time.
+	Offset      int64
+	Origin      string
+	Currency    string
+	AmountIn    float64
+	AmountOut   float64
+	Path        []ConversionHop // populated for multi-hop conversions
+	ReceiptHash string
+	Timestamp   time.Time
+}
+
+// ConversionLimits bounds the net (post-fee) output amount for one target
+// currency. Zero values leave that bound unenforced.
+type ConversionLimits struct {
+	Min float64
+	Max float64
+}
+
+// Converter exchanges Pi Coin for stablecoin at a configured rate.
+type Converter struct {
+	mu      sync.RWMutex
+	rate    float64 // stablecoin per Pi Coin
+	fee     float64 // flat fee deducted from the output
+	limits  map[string]ConversionLimits
+	journal []ConversionRecord
+	idem    idempotencyTable
+
+	failures      int
+	optimizeHook  OptimizeHook
+	optimizeAudit AuditSink
+
+	pairs   map[string]map[string]float64 // from -> to -> rate
+	maxHops int
+
+	quota ConverterQuota
+	usage map[string][]quotaUsage // origin -> recent conversions
+
+	quoteTTL time.Duration
+	quotes   map[string]ConversionQuote // token -> open quote
+
+	journalSubs      map[int]*convSub
+	nextJournalSubID int
+
+	stablecoinOnly bool
+	stablecoins    map[string]bool // currencies counted as on-chain stablecoins
+}
+
+func NewConverter(rate float64) *Converter {
+	return &Converter{rate: rate, limits: make(map[string]ConversionLimits)}
+}
+
+// SetFee sets the flat fee deducted from every conversion's output.
+func (c *Converter) SetFee(fee float64) {
+	c.mu.Lock()
+	c.fee = fee
+	c.mu.Unlock()
+}
+
+// SetConversionLimits configures the min/max net output for a target
+// currency.
+func (c *Converter) SetConversionLimits(currency string, limits ConversionLimits) {
+	c.mu.Lock()
+	c.limits[currency] = limits
+	c.mu.Unlock()
+}
+
+// checkLimits validates the net (post-fee) amount against the currency's
+// configured range.
+func (c *Converter) checkLimits(currency string, net float64) error {
+	c.mu.RLock()
+	limits, ok := c.limits[currency]
+	c.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+	if limits.Min > 0 && net < limits.Min {
+		return fmt.Errorf("%w: net %g below minimum %g for %s", ErrConversionAmountRange, net, limits.Min, currency)
+	}
+	if limits.Max > 0 && net > limits.Max {
+		return fmt.Errorf("%w: net %g above maximum %g for %s", ErrConversionAmountRange, net, limits.Max, currency)
+	}
+	return nil
+}
+
+// receiptHash derives the hash that links a conversion to its ledger entry.
+func receiptHash(origin string, amountIn, amountOut float64, ts time.Time) string {
+	payload := origin + "|" +
+		strconv.FormatFloat(amountIn, 'g', -1, 64) + "|" +
+		strconv.FormatFloat(amountOut, 'g', -1, 64) + "|" +
+		strconv.FormatInt(ts.UnixNano(), 10)
+	sum := sha256.Sum256([]byte(payload))
+	return hex.EncodeToString(sum[:])
+}
+
+// SetRate updates the conversion rate.
+func (c *Converter) SetRate(rate float64) {
+	c.mu.Lock()
+	c.rate = rate
+	c.mu.Unlock()
+}
+
+// Rate returns the current conversion rate.
+func (c *Converter) Rate() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.rate
+}
+
+// ConvertPiCoin converts amount of Pi Coin from origin into the target
+// currency and returns the net stablecoin amount after fees.
+func (c *Converter) ConvertPiCoin(origin, currency string, amount float64) (float64, error) {
+	if err := checkMaintenance(); err != nil {
+		return 0, err
+	}
+	if origin == "" {
+		return 0, fmt.Errorf("origin is required")
+	}
+	if amount <= 0 {
+		return 0, fmt.Errorf("amount must be positive, got %g", amount)
+	}
+	if err := c.checkTarget(currency); err != nil {
+		c.recordFailure()
+		return 0, err
+	}
+	if err := c.checkQuota(origin, amount); err != nil {
+		c.recordFailure()
+		return 0, err
+	}
+
+	c.mu.RLock()
+	fee := c.fee
+	c.mu.RUnlock()
+	out := amount*c.Rate() - fee
+	if err := c.checkLimits(currency, out); err != nil {
+		c.recordFailure()
+		return 0, err
+	}
+	now := time.Now()
+	rec := ConversionRecord{
+		Origin:      origin,
+		Currency:    currency,
+		AmountIn:    amount,
+		AmountOut:   out,
+		ReceiptHash: receiptHash(origin, amount, out, now),
+		Timestamp:   now,
+	}
+	c.appendJournal(rec)
+	return out, nil
+}
+
+// Journal returns a copy of the conversion journal.
+func (c *Converter) Journal() []ConversionRecord {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	out := make([]ConversionRecord, len(c.journal))
+	copy(out, c.journal)
+	return out
+}
@@ -0,0 +1,71 @@
+package stablecoin
+
+import (
+	"sync"
+	"time"
+)
+
+// conversionOutcome memoizes the result of one idempotent conversion.
+type conversionOutcome struct {
+	out  float64
+	err  error
+	done chan struct{}
+	at   time.Time
+}
+
+type idempotencyTable struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	results map[string]*conversionOutcome
+}
+
+// SetRequestIDTTL configures how long completed request IDs are remembered
+// before a retry would re-execute. Defaults to one hour.
+func (c *Converter) SetRequestIDTTL(ttl time.Duration) {
+	c.idem.mu.Lock()
+	c.idem.ttl = ttl
+	c.idem.mu.Unlock()
+}
+
+// ConvertPiCoinIdempotent executes a conversion at most once per requestID:
+// a repeated or concurrent call with the same ID waits for and returns the
+// original outcome instead of re-executing. IDs are evicted after the TTL.
+func (c *Converter) ConvertPiCoinIdempotent(requestID, origin, currency string, amount float64) (float64, error) {
+	if requestID == "" {
+		return c.ConvertPiCoin(origin, currency, amount)
+	}
+
+	c.idem.mu.Lock()
+	if c.idem.results == nil {
+		c.idem.results = make(map[string]*conversionOutcome)
+	}
+	ttl := c.idem.ttl
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+	// Lazily evict expired IDs.
+	for id, o := range c.idem.results {
+		select {
+		case <-o.done:
+			if time.Since(o.at) > ttl {
+				delete(c.idem.results, id)
+			}
+		default:
+		}
+	}
+
+	if o, ok := c.idem.results[requestID]; ok {
+		c.idem.mu.Unlock()
+		<-o.done
+		return o.out, o.err
+	}
+
+	o := &conversionOutcome{done: make(chan struct{})}
+	c.idem.results[requestID] = o
+	c.idem.mu.Unlock()
+
+	o.out, o.err = c.ConvertPiCoin(origin, currency, amount)
+	o.at = time.Now()
+	close(o.done)
+	return o.out, o.err
+}
@@ -0,0 +1,108 @@
+package stablecoin
+
+import "sync"
+
+// convSub buffers live journal appends for one subscriber until its
+// goroutine can deliver them.
+type convSub struct {
+	mu     sync.Mutex
+	queue  []ConversionRecord
+	notify chan struct{}
+}
+
+func (s *convSub) enqueue(rec ConversionRecord) {
+	s.mu.Lock()
+	s.queue = append(s.queue, rec)
+	s.mu.Unlock()
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (s *convSub) drain() []ConversionRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := s.queue
+	s.queue = nil
+	return out
+}
+
+// appendJournal assigns the record its monotonic offset, appends it and
+// fans it out to subscribers. The journal is append-only: records are never
+// mutated or removed, so an offset identifies one record forever.
+func (c *Converter) appendJournal(rec ConversionRecord) {
+	c.mu.Lock()
+	rec.Offset = int64(len(c.journal))
+	c.journal = append(c.journal, rec)
+	for _, sub := range c.journalSubs {
+		sub.enqueue(rec)
+	}
+	c.mu.Unlock()
+}
+
+// Subscribe streams journal records starting at fromOffset: first the
+// existing records from that offset, then live appends, with no gaps or
+// duplicates at the boundary — each offset is delivered exactly once per
+// subscription. The returned cancel function stops the stream and closes
+// the channel.
+func (c *Converter) Subscribe(fromOffset int64) (<-chan ConversionRecord, func()) {
+	c.mu.Lock()
+	if fromOffset < 0 {
+		fromOffset = 0
+	}
+	var historical []ConversionRecord
+	if fromOffset < int64(len(c.journal)) {
+		historical = make([]ConversionRecord, int64(len(c.journal))-fromOffset)
+		copy(historical, c.journal[fromOffset:])
+	}
+	sub := &convSub{notify: make(chan struct{}, 1)}
+	if c.journalSubs == nil {
+		c.journalSubs = make(map[int]*convSub)
+	}
+	id := c.nextJournalSubID
+	c.nextJournalSubID++
+	c.journalSubs[id] = sub
+	c.mu.Unlock()
+
+	out := make(chan ConversionRecord)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(out)
+		deliver := func(records []ConversionRecord) bool {
+			for _, rec := range records {
+				select {
+				case out <- rec:
+				case <-done:
+					return false
+				}
+			}
+			return true
+		}
+		if !deliver(historical) {
+			return
+		}
+		for {
+			select {
+			case <-sub.notify:
+				if !deliver(sub.drain()) {
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			c.mu.Lock()
+			delete(c.journalSubs, id)
+			c.mu.Unlock()
+			close(done)
+		})
+	}
+	return out, cancel
+}
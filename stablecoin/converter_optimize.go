@@ -0,0 +1,84 @@
+package stablecoin
+
+import (
+	"fmt"
+	"time"
+)
+
+// OptimizationEvent describes one SelfOptimize decision.
+type OptimizationEvent struct {
+	Timestamp    time.Time `json:"timestamp"`
+	FailureCount int       `json:"failureCount"`
+	OldRules     string    `json:"oldRules"`
+	NewRules     string    `json:"newRules"`
+}
+
+// OptimizeHook receives every optimization event.
+type OptimizeHook func(ev OptimizationEvent)
+
+// SetOptimizeHook registers a callback invoked on each SelfOptimize run.
+func (c *Converter) SetOptimizeHook(h OptimizeHook) {
+	c.mu.Lock()
+	c.optimizeHook = h
+	c.mu.Unlock()
+}
+
+// SetOptimizeAudit routes optimization events to the audit sink.
+func (c *Converter) SetOptimizeAudit(sink AuditSink) {
+	c.mu.Lock()
+	c.optimizeAudit = sink
+	c.mu.Unlock()
+}
+
+// recordFailure counts a failed conversion for SelfOptimize to react to.
+func (c *Converter) recordFailure() {
+	c.mu.Lock()
+	c.failures++
+	c.mu.Unlock()
+}
+
+func (c *Converter) rulesString() string {
+	return fmt.Sprintf("rate=%g fee=%g", c.rate, c.fee)
+}
+
+// SelfOptimize reviews recent failures and nudges the conversion rate to
+// compensate, emitting a structured event (instead of a stdout log line) so
+// operators can track behaviour changes over time.
+func (c *Converter) SelfOptimize() {
+	c.mu.Lock()
+	old := c.rulesString()
+	failures := c.failures
+	if failures > 0 {
+		// Back the rate off proportionally to the failure pressure.
+		c.rate *= 1 - 0.01*float64(failures)
+		if c.rate < 0 {
+			c.rate = 0
+		}
+		c.failures = 0
+	}
+	ev := OptimizationEvent{
+		Timestamp:    time.Now(),
+		FailureCount: failures,
+		OldRules:     old,
+		NewRules:     c.rulesString(),
+	}
+	hook := c.optimizeHook
+	audit := c.optimizeAudit
+	c.mu.Unlock()
+
+	if hook != nil {
+		hook(ev)
+	}
+	if audit != nil {
+		audit.WriteAudit(AuditRecord{
+			SchemaVersion: AuditSchemaVersion,
+			Timestamp:     ev.Timestamp,
+			Kind:          "converter_self_optimize",
+			Details: map[string]interface{}{
+				"failure_count": ev.FailureCount,
+				"rules_before":  ev.OldRules,
+				"rules_after":   ev.NewRules,
+			},
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package stablecoin
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrConverterQuota is returned when an origin exceeds its rolling
+// conversion quota.
+var ErrConverterQuota = errors.New("converter quota exceeded")
+
+// ConverterQuota bounds an origin's conversions over a rolling window.
+// Zero MaxCount or MaxAmount leaves that bound unenforced.
+type ConverterQuota struct {
+	Window    time.Duration
+	MaxCount  int
+	MaxAmount float64 // sum of AmountIn across the window
+}
+
+type quotaUsage struct {
+	at     time.Time
+	amount float64
+}
+
+// SetQuota configures the rolling throughput quota applied to every origin.
+func (c *Converter) SetQuota(q ConverterQuota) {
+	c.mu.Lock()
+	c.quota = q
+	if c.usage == nil {
+		c.usage = make(map[string][]quotaUsage)
+	}
+	c.mu.Unlock()
+}
+
+// checkQuota verifies the origin has headroom for one more conversion of
+// the given amount and, if so, records the usage. Callers hold no locks.
+func (c *Converter) checkQuota(origin string, amount float64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.quota.Window <= 0 {
+		return nil
+	}
+
+	cutoff := time.Now().Add(-c.quota.Window)
+	recent := c.usage[origin][:0]
+	total := 0.0
+	for _, u := range c.usage[origin] {
+		if u.at.After(cutoff) {
+			recent = append(recent, u)
+			total += u.amount
+		}
+	}
+
+	if c.quota.MaxCount > 0 && len(recent) >= c.quota.MaxCount {
+		c.usage[origin] = recent
+		return fmt.Errorf("%w: origin %s exceeded %d conversions in %s", ErrConverterQuota, origin, c.quota.MaxCount, c.quota.Window)
+	}
+	if c.quota.MaxAmount > 0 && total+amount > c.quota.MaxAmount {
+		c.usage[origin] = recent
+		return fmt.Errorf("%w: origin %s exceeded amount %g in %s", ErrConverterQuota, origin, c.quota.MaxAmount, c.quota.Window)
+	}
+
+	c.usage[origin] = append(recent, quotaUsage{at: time.Now(), amount: amount})
+	return nil
+}
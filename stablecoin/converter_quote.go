@@ -0,0 +1,139 @@
+package stablecoin
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrQuoteExpired is returned when executing against a quote token that is
+// unknown, already used, or past its validity window.
+var ErrQuoteExpired = errors.New("conversion quote expired")
+
+// ConversionQuote is a non-binding preview of a conversion: the same math
+// ConvertPiCoin would apply, with a token that locks the quoted rate and
+// fee for a short window.
+type ConversionQuote struct {
+	Token     string
+	Origin    string
+	Currency  string
+	AmountIn  float64
+	Rate      float64
+	Fee       float64
+	Net       float64
+	ExpiresAt time.Time
+}
+
+// SetQuoteTTL sets how long a quote's locked rate stays executable. The
+// default is 30 seconds.
+func (c *Converter) SetQuoteTTL(ttl time.Duration) {
+	c.mu.Lock()
+	c.quoteTTL = ttl
+	c.mu.Unlock()
+}
+
+// Quote computes what ConvertPiCoin would return for the given conversion
+// without executing it: no journal entry, no quota usage. The returned
+// token can be handed to ConvertQuoted within the TTL to execute at exactly
+// the quoted rate and fee, even if rates move in between.
+func (c *Converter) Quote(origin, currency string, amount float64) (ConversionQuote, error) {
+	if origin == "" {
+		return ConversionQuote{}, fmt.Errorf("origin is required")
+	}
+	if amount <= 0 {
+		return ConversionQuote{}, fmt.Errorf("amount must be positive, got %g", amount)
+	}
+	if err := c.checkTarget(currency); err != nil {
+		return ConversionQuote{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.purgeExpiredQuotes(time.Now())
+	net := amount*c.rate - c.fee
+	ttl := c.quoteTTL
+	if ttl <= 0 {
+		ttl = 30 * time.Second
+	}
+	quote := ConversionQuote{
+		Token:     newQuoteToken(),
+		Origin:    origin,
+		Currency:  currency,
+		AmountIn:  amount,
+		Rate:      c.rate,
+		Fee:       c.fee,
+		Net:       net,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if c.quotes == nil {
+		c.quotes = make(map[string]ConversionQuote)
+	}
+	c.quotes[quote.Token] = quote
+	return quote, nil
+}
+
+// ConvertQuoted executes a previously quoted conversion at its locked rate
+// and fee. The token is single-use; a stale or unknown token is rejected
+// with ErrQuoteExpired. Limits and quotas still apply at execution time.
+func (c *Converter) ConvertQuoted(token string) (float64, error) {
+	if err := checkMaintenance(); err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	quote, ok := c.quotes[token]
+	if ok {
+		delete(c.quotes, token)
+	}
+	c.mu.Unlock()
+	if !ok || time.Now().After(quote.ExpiresAt) {
+		return 0, ErrQuoteExpired
+	}
+
+	// Re-check the target alongside quota and limits: a quote issued
+	// before the stablecoin-only toggle flipped must not execute to fiat.
+	if err := c.checkTarget(quote.Currency); err != nil {
+		c.recordFailure()
+		return 0, err
+	}
+	if err := c.checkQuota(quote.Origin, quote.AmountIn); err != nil {
+		c.recordFailure()
+		return 0, err
+	}
+	if err := c.checkLimits(quote.Currency, quote.Net); err != nil {
+		c.recordFailure()
+		return 0, err
+	}
+
+	now := time.Now()
+	rec := ConversionRecord{
+		Origin:      quote.Origin,
+		Currency:    quote.Currency,
+		AmountIn:    quote.AmountIn,
+		AmountOut:   quote.Net,
+		ReceiptHash: receiptHash(quote.Origin, quote.AmountIn, quote.Net, now),
+		Timestamp:   now,
+	}
+	c.appendJournal(rec)
+	return quote.Net, nil
+}
+
+// purgeExpiredQuotes drops quotes past their validity window so tokens
+// that are never redeemed do not accumulate. Called with c.mu held, on
+// each insert: quoting is the only thing that grows the map, so sweeping
+// there bounds it without a background goroutine.
+func (c *Converter) purgeExpiredQuotes(now time.Time) {
+	for token, quote := range c.quotes {
+		if now.After(quote.ExpiresAt) {
+			delete(c.quotes, token)
+		}
+	}
+}
+
+func newQuoteToken() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
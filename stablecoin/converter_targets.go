@@ -0,0 +1,41 @@
+package stablecoin
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrFiatDisallowed is returned when a conversion targets a fiat currency
+// on a deployment restricted to on-chain stablecoins.
+var ErrFiatDisallowed = errors.New("fiat conversion targets are disallowed")
+
+// RegisterStablecoin marks currencies as on-chain stablecoins; anything not
+// registered is treated as fiat when the stablecoin-only restriction is on.
+func (c *Converter) RegisterStablecoin(currencies ...string) {
+	c.mu.Lock()
+	if c.stablecoins == nil {
+		c.stablecoins = make(map[string]bool)
+	}
+	for _, cur := range currencies {
+		c.stablecoins[cur] = true
+	}
+	c.mu.Unlock()
+}
+
+// SetStablecoinOnly restricts conversion targets to registered stablecoins,
+// closing fiat off-ramps on deployments that must not offer them.
+func (c *Converter) SetStablecoinOnly(on bool) {
+	c.mu.Lock()
+	c.stablecoinOnly = on
+	c.mu.Unlock()
+}
+
+// checkTarget rejects fiat targets while the restriction is on.
+func (c *Converter) checkTarget(currency string) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.stablecoinOnly || c.stablecoins[currency] {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrFiatDisallowed, currency)
+}
@@ -0,0 +1,331 @@
+package stablecoin
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestConversionLimits(t *testing.T) {
+	c := NewConverter(2.0)
+	c.SetConversionLimits("USD", ConversionLimits{Min: 10, Max: 100})
+
+	if _, err := c.ConvertPiCoin("alice", "USD", 4); !errors.Is(err, ErrConversionAmountRange) {
+		t.Fatalf("below-min conversion: %v, want ErrConversionAmountRange", err)
+	}
+	if _, err := c.ConvertPiCoin("alice", "USD", 200); !errors.Is(err, ErrConversionAmountRange) {
+		t.Fatalf("above-max conversion: %v, want ErrConversionAmountRange", err)
+	}
+	out, err := c.ConvertPiCoin("alice", "USD", 25)
+	if err != nil || out != 50 {
+		t.Fatalf("in-range conversion = %g, %v; want 50", out, err)
+	}
+}
+
+func TestConverterQuotaPerOrigin(t *testing.T) {
+	c := NewConverter(1.0)
+	c.SetQuota(ConverterQuota{Window: time.Minute, MaxCount: 2})
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.ConvertPiCoin("heavy", "USD", 1); err != nil {
+			t.Fatalf("conversion %d for heavy origin: %v", i, err)
+		}
+	}
+	if _, err := c.ConvertPiCoin("heavy", "USD", 1); !errors.Is(err, ErrConverterQuota) {
+		t.Fatalf("over-quota conversion: %v, want ErrConverterQuota", err)
+	}
+	// A different origin is unaffected.
+	if _, err := c.ConvertPiCoin("light", "USD", 1); err != nil {
+		t.Fatalf("other origin blocked by heavy origin's quota: %v", err)
+	}
+}
+
+func TestConverterQuotaCoversPaths(t *testing.T) {
+	c := NewConverter(1.0)
+	c.SetPairRate("PI", "USDC", 1.0)
+	c.SetQuota(ConverterQuota{Window: time.Minute, MaxCount: 2})
+
+	// Path conversions draw on the same rolling window...
+	for i := 0; i < 2; i++ {
+		if _, _, err := c.ConvertPath("heavy", "PI", "USDC", 1); err != nil {
+			t.Fatalf("path conversion %d for heavy origin: %v", i, err)
+		}
+	}
+	if _, _, err := c.ConvertPath("heavy", "PI", "USDC", 1); !errors.Is(err, ErrConverterQuota) {
+		t.Fatalf("over-quota path conversion: %v, want ErrConverterQuota", err)
+	}
+	// ...shared with the direct route, so it cannot be used to top up.
+	if _, err := c.ConvertPiCoin("heavy", "USDC", 1); !errors.Is(err, ErrConverterQuota) {
+		t.Fatalf("direct conversion after path quota exhausted: %v, want ErrConverterQuota", err)
+	}
+	if _, _, err := c.ConvertPath("light", "PI", "USDC", 1); err != nil {
+		t.Fatalf("other origin blocked by heavy origin's quota: %v", err)
+	}
+}
+
+func TestQuoteMatchesExecution(t *testing.T) {
+	c := NewConverter(2.0)
+	c.SetFee(0.5)
+
+	quote, err := c.Quote("alice", "USD", 10)
+	if err != nil {
+		t.Fatalf("Quote: %v", err)
+	}
+	if quote.Net != 19.5 {
+		t.Fatalf("quoted net = %g, want 19.5", quote.Net)
+	}
+
+	// Rates move after the quote; execution still honours the locked rate.
+	c.SetRate(5.0)
+	out, err := c.ConvertQuoted(quote.Token)
+	if err != nil {
+		t.Fatalf("ConvertQuoted: %v", err)
+	}
+	if out != quote.Net {
+		t.Fatalf("executed %g, quoted %g; quote did not lock the rate", out, quote.Net)
+	}
+	// Tokens are single-use.
+	if _, err := c.ConvertQuoted(quote.Token); !errors.Is(err, ErrQuoteExpired) {
+		t.Fatalf("token reuse: %v, want ErrQuoteExpired", err)
+	}
+}
+
+func TestStaleQuoteTokenRejectedAndPurged(t *testing.T) {
+	c := NewConverter(2.0)
+	c.SetQuoteTTL(time.Millisecond)
+
+	stale, err := c.Quote("alice", "USD", 10)
+	if err != nil {
+		t.Fatalf("Quote: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if _, err := c.ConvertQuoted(stale.Token); !errors.Is(err, ErrQuoteExpired) {
+		t.Fatalf("stale token: %v, want ErrQuoteExpired", err)
+	}
+	if _, err := c.ConvertQuoted("no-such-token"); !errors.Is(err, ErrQuoteExpired) {
+		t.Fatalf("unknown token: %v, want ErrQuoteExpired", err)
+	}
+
+	// Expired, never-redeemed quotes are swept when new quotes are issued.
+	never, _ := c.Quote("alice", "USD", 10)
+	time.Sleep(5 * time.Millisecond)
+	fresh, _ := c.Quote("alice", "USD", 10)
+	c.mu.RLock()
+	_, staleHeld := c.quotes[never.Token]
+	_, freshHeld := c.quotes[fresh.Token]
+	c.mu.RUnlock()
+	if staleHeld {
+		t.Fatal("expired unredeemed quote still in the table after a new Quote")
+	}
+	if !freshHeld {
+		t.Fatal("fresh quote missing from the table")
+	}
+}
+
+func TestStablecoinOnlyTargets(t *testing.T) {
+	c := NewConverter(1.0)
+	c.RegisterStablecoin("USDC")
+	c.SetStablecoinOnly(true)
+
+	if _, err := c.ConvertPiCoin("alice", "USD", 10); !errors.Is(err, ErrFiatDisallowed) {
+		t.Fatalf("fiat target with restriction on: %v, want ErrFiatDisallowed", err)
+	}
+	if _, err := c.ConvertPiCoin("alice", "USDC", 10); err != nil {
+		t.Fatalf("stablecoin target rejected: %v", err)
+	}
+
+	c.SetStablecoinOnly(false)
+	if _, err := c.ConvertPiCoin("alice", "USD", 10); err != nil {
+		t.Fatalf("fiat target with restriction off: %v", err)
+	}
+}
+
+func TestStablecoinOnlyCoversPathsAndQuotes(t *testing.T) {
+	c := NewConverter(1.0)
+	c.RegisterStablecoin("USDC")
+	c.SetPairRate("PI", "USDC", 2.0)
+	c.SetPairRate("USDC", "USD", 1.0)
+	c.SetStablecoinOnly(true)
+
+	// Multi-hop conversions must not off-ramp to fiat either.
+	if _, _, err := c.ConvertPath("alice", "PI", "USD", 10); !errors.Is(err, ErrFiatDisallowed) {
+		t.Fatalf("fiat-terminated path with restriction on: %v, want ErrFiatDisallowed", err)
+	}
+	if _, _, err := c.ConvertPath("alice", "PI", "USDC", 10); err != nil {
+		t.Fatalf("stablecoin-terminated path rejected: %v", err)
+	}
+
+	// A quote opened before the toggle flips cannot execute to fiat.
+	c.SetStablecoinOnly(false)
+	quote, err := c.Quote("alice", "USD", 10)
+	if err != nil {
+		t.Fatalf("Quote: %v", err)
+	}
+	c.SetStablecoinOnly(true)
+	if _, err := c.ConvertQuoted(quote.Token); !errors.Is(err, ErrFiatDisallowed) {
+		t.Fatalf("pre-toggle quote executed: %v, want ErrFiatDisallowed", err)
+	}
+}
+
+func TestIdempotentConversionExecutesOnce(t *testing.T) {
+	c := NewConverter(2.0)
+
+	var wg sync.WaitGroup
+	outs := make([]float64, 10)
+	for i := range outs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			out, err := c.ConvertPiCoinIdempotent("req-1", "alice", "USD", 10)
+			if err != nil {
+				t.Errorf("ConvertPiCoinIdempotent: %v", err)
+			}
+			outs[i] = out
+		}(i)
+	}
+	wg.Wait()
+
+	for i, out := range outs {
+		if out != 20 {
+			t.Fatalf("call %d returned %g, want 20", i, out)
+		}
+	}
+	if got := len(c.Journal()); got != 1 {
+		t.Fatalf("journal has %d receipts for one request ID, want 1", got)
+	}
+}
+
+func TestConversionPaths(t *testing.T) {
+	c := NewConverter(1.0)
+	c.SetPairRate("PI", "USDC", 2.0)
+	c.SetPairRate("USDC", "EUR", 0.5)
+
+	// Direct one-hop.
+	out, hops, err := c.ConvertPath("alice", "PI", "USDC", 10)
+	if err != nil || len(hops) != 1 || out != 20 {
+		t.Fatalf("direct path: out=%g hops=%d err=%v; want 20 over 1 hop", out, len(hops), err)
+	}
+
+	// Two-hop through USDC.
+	out, hops, err = c.ConvertPath("alice", "PI", "EUR", 10)
+	if err != nil || len(hops) != 2 || out != 10 {
+		t.Fatalf("two-hop path: out=%g hops=%d err=%v; want 10 over 2 hops", out, len(hops), err)
+	}
+
+	if _, _, err := c.ConvertPath("alice", "PI", "JPY", 10); !errors.Is(err, ErrNoConversionPath) {
+		t.Fatalf("unreachable target: %v, want ErrNoConversionPath", err)
+	}
+}
+
+func TestJournalSubscribeMidStreamExactlyOnce(t *testing.T) {
+	c := NewConverter(1.0)
+	for i := 0; i < 4; i++ {
+		c.ConvertPiCoin("alice", "USD", 10)
+	}
+
+	ch, cancel := c.Subscribe(2)
+	defer cancel()
+
+	var offsets []int64
+	for i := 0; i < 2; i++ {
+		offsets = append(offsets, (<-ch).Offset)
+	}
+	c.ConvertPiCoin("alice", "USD", 10)
+	select {
+	case rec := <-ch:
+		offsets = append(offsets, rec.Offset)
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for live record; got %v", offsets)
+	}
+
+	want := []int64{2, 3, 4}
+	for i := range want {
+		if offsets[i] != want[i] {
+			t.Fatalf("offsets = %v, want %v: gap or duplicate at the boundary", offsets, want)
+		}
+	}
+}
+
+func TestReconcileConversions(t *testing.T) {
+	c := NewConverter(1.0)
+	l := NewLedger()
+
+	// Two matched pairs: the ledger entry's data is the receipt hash.
+	for i := 0; i < 2; i++ {
+		c.ConvertPiCoin("alice", "USD", 10)
+	}
+	for _, rec := range c.Journal() {
+		l.AddAmountEntry(rec.ReceiptHash, rec.AmountOut)
+	}
+	// One orphan conversion (no ledger entry) and one orphan ledger entry.
+	c.ConvertPiCoin("alice", "USD", 99)
+	l.AddAmountEntry("entry-without-conversion", 5)
+
+	report := c.ReconcileConversions(l, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	if report.Matched != 2 {
+		t.Fatalf("Matched = %d, want 2", report.Matched)
+	}
+	if len(report.OrphanConversions) != 1 || report.OrphanConversions[0].AmountIn != 99 {
+		t.Fatalf("OrphanConversions = %+v, want the 99 conversion", report.OrphanConversions)
+	}
+	if len(report.OrphanEntries) != 1 || report.OrphanEntries[0].Data != "entry-without-conversion" {
+		t.Fatalf("OrphanEntries = %+v, want the unmatched entry", report.OrphanEntries)
+	}
+}
+
+func TestSelfOptimizeEmitsEvent(t *testing.T) {
+	c := NewConverter(2.0)
+	c.SetConversionLimits("USD", ConversionLimits{Max: 1})
+
+	var events []OptimizationEvent
+	c.SetOptimizeHook(func(ev OptimizationEvent) { events = append(events, ev) })
+
+	// Drive failures, then optimize.
+	c.ConvertPiCoin("alice", "USD", 100)
+	c.ConvertPiCoin("alice", "USD", 100)
+	c.SelfOptimize()
+
+	if len(events) != 1 {
+		t.Fatalf("hook received %d events, want 1", len(events))
+	}
+	ev := events[0]
+	if ev.FailureCount != 2 || ev.OldRules == ev.NewRules || ev.OldRules == "" {
+		t.Fatalf("event = %+v; want failure count 2 and changed rules", ev)
+	}
+}
+
+func TestValueCheckPerOriginTolerance(t *testing.T) {
+	vc := NewValueCheck(1.0)
+	vc.SetTolerance("rewards", 0.01)
+
+	// 1.0078125 is exactly representable, keeping the deviation strictly
+	// inside the ±0.01 tolerance without float rounding at the boundary.
+	rewards := Transaction{Origin: "rewards", Amount: 1.0078125}
+	if err := vc.Check(rewards); err != nil {
+		t.Fatalf("rewards within tolerance: %v", err)
+	}
+	// The same deviation from an origin held to exact fails.
+	mining := Transaction{Origin: "mining", Amount: 1.0078125}
+	if err := vc.Check(mining); err == nil {
+		t.Fatal("mining deviation passed despite exact tolerance")
+	}
+	rewardsFar := Transaction{Origin: "rewards", Amount: 1.05}
+	if err := vc.Check(rewardsFar); err == nil {
+		t.Fatal("rewards beyond tolerance passed")
+	}
+}
+
+func TestConverterFailureCounter(t *testing.T) {
+	c := NewConverter(1.0)
+	c.SetConversionLimits("USD", ConversionLimits{Max: 1})
+	var calls int32
+	c.SetOptimizeHook(func(ev OptimizationEvent) { atomic.AddInt32(&calls, 1) })
+	c.ConvertPiCoin("alice", "USD", 100)
+	c.SelfOptimize()
+	c.SelfOptimize()
+	if atomic.LoadInt32(&calls) != 2 {
+		t.Fatalf("hook calls = %d, want 2", calls)
+	}
+}
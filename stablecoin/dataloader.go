@@ -0,0 +1,120 @@
+package stablecoin
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// LedgerBatchFunc fetches a batch of ledger entries by id in one store
+// call.
+type LedgerBatchFunc func(ids []int64) (map[int64]LedgerEntry, error)
+
+type loaderResult struct {
+	entry LedgerEntry
+	err   error
+}
+
+// LedgerLoader coalesces individual entry lookups issued while resolving a
+// query into one batched store call per tick, de-duplicating ids, so a list
+// of N entries costs one read instead of N.
+type LedgerLoader struct {
+	batch    LedgerBatchFunc
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	pending map[int64][]chan loaderResult
+	timer   *time.Timer
+}
+
+func NewLedgerLoader(batch LedgerBatchFunc, wait time.Duration, maxBatch int) *LedgerLoader {
+	if wait <= 0 {
+		wait = time.Millisecond
+	}
+	if maxBatch <= 0 {
+		maxBatch = 100
+	}
+	return &LedgerLoader{
+		batch:    batch,
+		wait:     wait,
+		maxBatch: maxBatch,
+		pending:  make(map[int64][]chan loaderResult),
+	}
+}
+
+// Load returns the entry for id, batching with any other Load calls made
+// within the same tick.
+func (dl *LedgerLoader) Load(id int64) (LedgerEntry, error) {
+	ch := make(chan loaderResult, 1)
+
+	dl.mu.Lock()
+	dl.pending[id] = append(dl.pending[id], ch)
+	if len(dl.pending) >= dl.maxBatch {
+		dl.flushLocked()
+	} else if dl.timer == nil {
+		dl.timer = time.AfterFunc(dl.wait, func() {
+			dl.mu.Lock()
+			dl.flushLocked()
+			dl.mu.Unlock()
+		})
+	}
+	dl.mu.Unlock()
+
+	res := <-ch
+	return res.entry, res.err
+}
+
+// flushLocked dispatches the pending batch. Callers hold dl.mu.
+func (dl *LedgerLoader) flushLocked() {
+	if dl.timer != nil {
+		dl.timer.Stop()
+		dl.timer = nil
+	}
+	if len(dl.pending) == 0 {
+		return
+	}
+	pending := dl.pending
+	dl.pending = make(map[int64][]chan loaderResult)
+
+	ids := make([]int64, 0, len(pending))
+	for id := range pending {
+		ids = append(ids, id)
+	}
+
+	go func() {
+		entries, err := dl.batch(ids)
+		for id, waiters := range pending {
+			res := loaderResult{err: err}
+			if err == nil {
+				entry, ok := entries[id]
+				if !ok {
+					res.err = fmt.Errorf("ledger entry %d not found", id)
+				} else {
+					res.entry = entry
+				}
+			}
+			for _, ch := range waiters {
+				ch <- res
+			}
+		}
+	}()
+}
+
+// BatchEntries is a LedgerBatchFunc backed by this ledger: one scan serves
+// the whole batch.
+func (l *Ledger) BatchEntries(ids []int64) (map[int64]LedgerEntry, error) {
+	want := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		want[id] = true
+	}
+	out := make(map[int64]LedgerEntry, len(ids))
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for _, e := range l.entries {
+		if want[e.ID] {
+			out[e.ID] = e
+		}
+	}
+	return out, nil
+}
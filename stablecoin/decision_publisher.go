@@ -0,0 +1,107 @@
+package stablecoin
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// OverflowPolicy controls what Publish does when the buffer is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock makes Publish wait for space.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropNewest discards the incoming record.
+	OverflowDropNewest
+	// OverflowDropOldest discards the oldest buffered record to make room.
+	OverflowDropOldest
+)
+
+// DecisionPublisher decouples enforcement from a slow downstream sink with
+// a bounded buffer. It is itself a DecisionSink, so it slots between the
+// enforcer and any real sink.
+type DecisionPublisher struct {
+	sink    DecisionSink
+	buf     chan DecisionRecord
+	policy  OverflowPolicy
+	dropped uint64
+
+	closeOnce sync.Once
+	done      chan struct{}
+	wg        sync.WaitGroup
+}
+
+func NewDecisionPublisher(sink DecisionSink, bufferSize int, policy OverflowPolicy) *DecisionPublisher {
+	if bufferSize <= 0 {
+		bufferSize = 256
+	}
+	p := &DecisionPublisher{
+		sink:   sink,
+		buf:    make(chan DecisionRecord, bufferSize),
+		policy: policy,
+		done:   make(chan struct{}),
+	}
+	p.wg.Add(1)
+	go p.run()
+	return p
+}
+
+func (p *DecisionPublisher) run() {
+	defer p.wg.Done()
+	for {
+		select {
+		case rec := <-p.buf:
+			p.sink.Publish(rec)
+		case <-p.done:
+			// Drain what is already buffered, then exit.
+			for {
+				select {
+				case rec := <-p.buf:
+					p.sink.Publish(rec)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// Publish enqueues the record, applying the overflow policy when the buffer
+// is full. It never returns an error for dropped records; drops are counted.
+func (p *DecisionPublisher) Publish(rec DecisionRecord) error {
+	switch p.policy {
+	case OverflowBlock:
+		p.buf <- rec
+	case OverflowDropNewest:
+		select {
+		case p.buf <- rec:
+		default:
+			atomic.AddUint64(&p.dropped, 1)
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case p.buf <- rec:
+				return nil
+			default:
+			}
+			select {
+			case <-p.buf:
+				atomic.AddUint64(&p.dropped, 1)
+			default:
+			}
+		}
+	}
+	return nil
+}
+
+// Dropped reports how many records have been discarded due to overflow.
+func (p *DecisionPublisher) Dropped() uint64 {
+	return atomic.LoadUint64(&p.dropped)
+}
+
+// Close stops the publisher after draining the buffer.
+func (p *DecisionPublisher) Close() {
+	p.closeOnce.Do(func() { close(p.done) })
+	p.wg.Wait()
+}
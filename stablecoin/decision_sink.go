@@ -0,0 +1,119 @@
+package stablecoin
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/syslog"
+	"os"
+	"sync"
+	"time"
+)
+
+// DecisionRecord is the structured form of one enforcement decision.
+type DecisionRecord struct {
+	SchemaVersion int       `json:"schema_version"`
+	TransactionID string    `json:"transaction_id"`
+	Allowed       bool      `json:"allowed"`
+	Reason        string    `json:"reason"`
+	Monitored     bool      `json:"monitored"`
+	Timestamp     time.Time `json:"timestamp"`
+
+	// Tags are the transaction's allow-listed annotations; additive since
+	// schema version 1.
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// DecisionSink receives enforcement decisions. Implementations must be safe
+// for concurrent use.
+type DecisionSink interface {
+	Publish(rec DecisionRecord) error
+}
+
+// StdoutSink writes one JSON record per decision to stdout.
+type StdoutSink struct {
+	mu sync.Mutex
+}
+
+func (s *StdoutSink) Publish(rec DecisionRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return json.NewEncoder(os.Stdout).Encode(rec)
+}
+
+// FileSink appends one JSON record per decision to a file.
+type FileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewFileSink(path string) (*FileSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{file: f}, nil
+}
+
+func (s *FileSink) Publish(rec DecisionRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return json.NewEncoder(s.file).Encode(rec)
+}
+
+func (s *FileSink) Close() error { return s.file.Close() }
+
+// SyslogSink forwards decisions to the local syslog daemon.
+type SyslogSink struct {
+	writer *syslog.Writer
+}
+
+func NewSyslogSink(tag string) (*SyslogSink, error) {
+	w, err := syslog.New(syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &SyslogSink{writer: w}, nil
+}
+
+func (s *SyslogSink) Publish(rec DecisionRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return s.writer.Info(string(data))
+}
+
+// MultiSink tees decisions to every child sink, returning the first error
+// after all sinks have been attempted.
+type MultiSink struct {
+	sinks []DecisionSink
+}
+
+func NewMultiSink(sinks ...DecisionSink) *MultiSink {
+	return &MultiSink{sinks: sinks}
+}
+
+func (m *MultiSink) Publish(rec DecisionRecord) error {
+	var firstErr error
+	for _, s := range m.sinks {
+		if err := s.Publish(rec); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// NewDecisionSink builds a sink from a config name: "stdout", "file:<path>"
+// or "syslog".
+func NewDecisionSink(spec string) (DecisionSink, error) {
+	switch {
+	case spec == "stdout":
+		return &StdoutSink{}, nil
+	case len(spec) > 5 && spec[:5] == "file:":
+		return NewFileSink(spec[5:])
+	case spec == "syslog":
+		return NewSyslogSink("pi-supernode")
+	default:
+		return nil, fmt.Errorf("unknown decision sink %q", spec)
+	}
+}
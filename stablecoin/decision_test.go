@@ -0,0 +1,193 @@
+package stablecoin
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memDecisionSink collects published decisions in memory.
+type memDecisionSink struct {
+	mu      sync.Mutex
+	records []DecisionRecord
+	err     error
+}
+
+func (s *memDecisionSink) Publish(rec DecisionRecord) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.mu.Lock()
+	s.records = append(s.records, rec)
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *memDecisionSink) published() []DecisionRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]DecisionRecord(nil), s.records...)
+}
+
+func TestMultiSinkTeesToEverySink(t *testing.T) {
+	a := &memDecisionSink{}
+	b := &memDecisionSink{}
+	multi := NewMultiSink(a, b)
+
+	rec := DecisionRecord{TransactionID: "tx-1", Allowed: false, Reason: "geo blocked"}
+	if err := multi.Publish(rec); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	for name, sink := range map[string]*memDecisionSink{"first": a, "second": b} {
+		got := sink.published()
+		if len(got) != 1 || got[0].TransactionID != "tx-1" {
+			t.Fatalf("%s sink received %+v, want the published record", name, got)
+		}
+	}
+
+	// A failing child does not starve its siblings, and the error surfaces.
+	failing := NewMultiSink(&memDecisionSink{err: errors.New("sink down")}, b)
+	if err := failing.Publish(rec); err == nil {
+		t.Fatal("child sink failure was swallowed")
+	}
+	if got := b.published(); len(got) != 2 {
+		t.Fatalf("healthy sink received %d records, want 2", len(got))
+	}
+}
+
+// stallingSink blocks every Publish on gate, signalling each arrival on
+// started, so tests can park the publisher's worker deterministically.
+type stallingSink struct {
+	memDecisionSink
+	started chan struct{}
+	gate    chan struct{}
+}
+
+func newStallingSink() *stallingSink {
+	return &stallingSink{started: make(chan struct{}, 16), gate: make(chan struct{})}
+}
+
+func (s *stallingSink) Publish(rec DecisionRecord) error {
+	s.started <- struct{}{}
+	<-s.gate
+	return s.memDecisionSink.Publish(rec)
+}
+
+// stallPublisher builds a publisher over a stalled sink whose worker is
+// parked holding tx-0, leaving the whole buffer for the test to fill.
+func stallPublisher(t *testing.T, bufferSize int, policy OverflowPolicy) (*DecisionPublisher, *stallingSink) {
+	t.Helper()
+	sink := newStallingSink()
+	p := NewDecisionPublisher(sink, bufferSize, policy)
+	p.Publish(DecisionRecord{TransactionID: "tx-0"})
+	select {
+	case <-sink.started:
+	case <-time.After(time.Second):
+		t.Fatal("publisher worker never picked up the first record")
+	}
+	return p, sink
+}
+
+func publishedIDs(sink *stallingSink) []string {
+	var ids []string
+	for _, rec := range sink.published() {
+		ids = append(ids, rec.TransactionID)
+	}
+	return ids
+}
+
+func TestDecisionPublisherDropNewest(t *testing.T) {
+	p, sink := stallPublisher(t, 2, OverflowDropNewest)
+	for i := 1; i <= 4; i++ {
+		p.Publish(DecisionRecord{TransactionID: fmt.Sprintf("tx-%d", i)})
+	}
+	if got := p.Dropped(); got != 2 {
+		t.Fatalf("Dropped() = %d, want 2", got)
+	}
+	close(sink.gate)
+	p.Close()
+
+	want := []string{"tx-0", "tx-1", "tx-2"}
+	if got := publishedIDs(sink); len(got) != len(want) || got[1] != "tx-1" || got[2] != "tx-2" {
+		t.Fatalf("delivered %v, want %v: drop-newest must keep the earliest buffered records", got, want)
+	}
+}
+
+func TestDecisionPublisherDropOldest(t *testing.T) {
+	p, sink := stallPublisher(t, 2, OverflowDropOldest)
+	for i := 1; i <= 4; i++ {
+		p.Publish(DecisionRecord{TransactionID: fmt.Sprintf("tx-%d", i)})
+	}
+	if got := p.Dropped(); got != 2 {
+		t.Fatalf("Dropped() = %d, want 2", got)
+	}
+	close(sink.gate)
+	p.Close()
+
+	want := []string{"tx-0", "tx-3", "tx-4"}
+	if got := publishedIDs(sink); len(got) != len(want) || got[1] != "tx-3" || got[2] != "tx-4" {
+		t.Fatalf("delivered %v, want %v: drop-oldest must keep the newest records", got, want)
+	}
+}
+
+func TestDecisionPublisherBlocks(t *testing.T) {
+	p, sink := stallPublisher(t, 1, OverflowBlock)
+	p.Publish(DecisionRecord{TransactionID: "tx-1"}) // fills the buffer
+
+	unblocked := make(chan struct{})
+	go func() {
+		p.Publish(DecisionRecord{TransactionID: "tx-2"})
+		close(unblocked)
+	}()
+	select {
+	case <-unblocked:
+		t.Fatal("Publish returned against a full buffer under OverflowBlock")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(sink.gate)
+	select {
+	case <-unblocked:
+	case <-time.After(time.Second):
+		t.Fatal("Publish stayed blocked after the sink drained")
+	}
+	p.Close()
+	if got := p.Dropped(); got != 0 {
+		t.Fatalf("Dropped() = %d under OverflowBlock, want 0", got)
+	}
+	if got := publishedIDs(sink); len(got) != 3 {
+		t.Fatalf("delivered %v, want all three records", got)
+	}
+}
+
+func TestSeverityRouterAlertsOnHighSeverity(t *testing.T) {
+	alerts := &memDecisionSink{}
+	metrics := NewMetrics()
+	sr := NewSeverityRouter(alerts, SeverityHigh, metrics)
+	sr.MapReason("geo blocked", SeverityHigh)
+	sr.MapReason("kyc level", SeverityMedium)
+	sr.MapReason("kyc level too low", SeverityHigh) // longest prefix wins
+
+	publish := func(id, reason string, allowed bool) {
+		if err := sr.Publish(DecisionRecord{TransactionID: id, Reason: reason, Allowed: allowed}); err != nil {
+			t.Fatalf("Publish(%s): %v", id, err)
+		}
+	}
+	publish("tx-1", "geo blocked: origin in KP", false)
+	publish("tx-2", "kyc level unverified", false)
+	publish("tx-3", "kyc level too low for amount", false)
+	publish("tx-4", "quota exceeded", false)
+	publish("tx-5", "geo blocked: origin in KP", true) // allowed decisions never alert
+
+	got := alerts.published()
+	if len(got) != 2 || got[0].TransactionID != "tx-1" || got[1].TransactionID != "tx-3" {
+		t.Fatalf("alert sink received %+v, want only the high-severity rejections", got)
+	}
+	for severity, want := range map[string]uint64{"high": 3, "medium": 1, "low": 1} {
+		if got := metrics.Counter("decisions_by_severity_total", map[string]string{"severity": severity}).Value(); got != want {
+			t.Fatalf("decisions_by_severity_total{severity=%s} = %d, want %d", severity, got, want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package stablecoin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrUnverifiedDestination is returned when a transfer targets a recipient
+// address whose ownership has not been verified.
+var ErrUnverifiedDestination = errors.New("destination not verified")
+
+// DestinationVerifier answers whether a recipient address belongs to a
+// whitelisted or otherwise verified account. Implementations must be safe
+// for concurrent use.
+type DestinationVerifier interface {
+	VerifyDestination(ctx context.Context, recipient string) (bool, error)
+}
+
+// SetDestinationVerifier enables destination ownership enforcement: every
+// transaction's recipient must be verified before it can pass. Verifier
+// errors fail closed. Nil disables the check.
+func (re *RegulatoryEnforcer) SetDestinationVerifier(v DestinationVerifier) {
+	re.destinations = v
+}
+
+// checkDestination applies the configured verifier, returning a rejection
+// result when the recipient is unverified or the verifier fails.
+func (re *RegulatoryEnforcer) checkDestination(ctx context.Context, tx Transaction) (EnforcementResult, bool) {
+	if re.destinations == nil {
+		return EnforcementResult{}, false
+	}
+	ok, err := re.destinations.VerifyDestination(ctx, tx.Recipient)
+	if err != nil {
+		// Fail closed, mirroring KYC provider errors.
+		return EnforcementResult{
+			Allowed: false,
+			Reason:  fmt.Sprintf("destination verification failed: %v", err),
+		}, true
+	}
+	if !ok {
+		return EnforcementResult{
+			Allowed: false,
+			Reason:  fmt.Sprintf("%v: %s", ErrUnverifiedDestination, tx.Recipient),
+		}, true
+	}
+	return EnforcementResult{}, false
+}
@@ -0,0 +1,56 @@
+package stablecoin
+
+import (
+	"context"
+	"sync"
+)
+
+// BatchResult pairs one transaction's enforcement outcome with its position
+// in the input slice.
+type BatchResult struct {
+	Index  int
+	Result EnforcementResult
+	Err    error
+}
+
+// indexedTx carries a transaction together with its input position, so
+// results map back deterministically no matter how workers interleave.
+type indexedTx struct {
+	index int
+	tx    Transaction
+}
+
+// EnforceBatch enforces a slice of transactions concurrently across the
+// given number of workers. Workers process in whatever order scheduling
+// yields, but each result is written to its transaction's input slot, so
+// results[i] always corresponds to txs[i]. A non-positive worker count runs
+// sequentially.
+func (re *RegulatoryEnforcer) EnforceBatch(ctx context.Context, txs []Transaction, workers int) []BatchResult {
+	results := make([]BatchResult, len(txs))
+	if workers <= 1 {
+		for i, tx := range txs {
+			res, err := re.EnforceTransaction(ctx, tx)
+			results[i] = BatchResult{Index: i, Result: res, Err: err}
+		}
+		return results
+	}
+
+	jobs := make(chan indexedTx)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				res, err := re.EnforceTransaction(ctx, job.tx)
+				results[job.index] = BatchResult{Index: job.index, Result: res, Err: err}
+			}
+		}()
+	}
+	for i, tx := range txs {
+		jobs <- indexedTx{index: i, tx: tx}
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
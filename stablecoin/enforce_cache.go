@@ -0,0 +1,86 @@
+package stablecoin
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+type enforceCacheEntry struct {
+	key    string
+	result EnforcementResult
+	at     time.Time
+}
+
+// CachingEnforcer memoizes enforcement results keyed by the canonical
+// transaction hash, so semantically identical submissions (same values,
+// any field order at the wire level) share one decision. Entries expire
+// after the TTL and the cache is LRU-bounded; evictions are counted.
+type CachingEnforcer struct {
+	inner      Enforcer
+	ttl        time.Duration
+	maxEntries int
+	metrics    *Metrics
+
+	mu    sync.Mutex
+	lru   *list.List
+	cache map[string]*list.Element
+}
+
+func NewCachingEnforcer(inner Enforcer, ttl time.Duration, maxEntries int, metrics *Metrics) *CachingEnforcer {
+	if maxEntries <= 0 {
+		maxEntries = 4096
+	}
+	if metrics == nil {
+		metrics = DefaultMetrics
+	}
+	return &CachingEnforcer{
+		inner:      inner,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		metrics:    metrics,
+		lru:        list.New(),
+		cache:      make(map[string]*list.Element),
+	}
+}
+
+func (ce *CachingEnforcer) EnforceTransaction(ctx context.Context, tx Transaction) (EnforcementResult, error) {
+	key := tx.CanonicalHash()
+
+	ce.mu.Lock()
+	if el, ok := ce.cache[key]; ok {
+		entry := el.Value.(*enforceCacheEntry)
+		if ce.ttl <= 0 || time.Since(entry.at) < ce.ttl {
+			ce.lru.MoveToFront(el)
+			result := entry.result
+			ce.mu.Unlock()
+			ce.metrics.Counter("enforce_cache_hits_total", nil).Inc()
+			return result, nil
+		}
+		ce.lru.Remove(el)
+		delete(ce.cache, key)
+		ce.metrics.Counter("enforce_cache_evictions_total", nil).Inc()
+	}
+	ce.mu.Unlock()
+	ce.metrics.Counter("enforce_cache_misses_total", nil).Inc()
+
+	result, err := ce.inner.EnforceTransaction(ctx, tx)
+	if err != nil {
+		return result, err
+	}
+
+	ce.mu.Lock()
+	if _, ok := ce.cache[key]; !ok {
+		el := ce.lru.PushFront(&enforceCacheEntry{key: key, result: result, at: time.Now()})
+		ce.cache[key] = el
+		for ce.lru.Len() > ce.maxEntries {
+			oldest := ce.lru.Back()
+			ce.lru.Remove(oldest)
+			delete(ce.cache, oldest.Value.(*enforceCacheEntry).key)
+			ce.metrics.Counter("enforce_cache_evictions_total", nil).Inc()
+		}
+	}
+	ce.mu.Unlock()
+	return result, nil
+}
@@ -0,0 +1,241 @@
+package stablecoin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+// destStub verifies recipients against a fixed set, or fails outright.
+type destStub struct {
+	verified map[string]bool
+	err      error
+}
+
+func (d destStub) VerifyDestination(ctx context.Context, recipient string) (bool, error) {
+	return d.verified[recipient], d.err
+}
+
+func TestDestinationVerification(t *testing.T) {
+	re := newTestEnforcer(KYCFull)
+	re.SetDestinationVerifier(destStub{verified: map[string]bool{"bob": true}})
+
+	if result, err := re.EnforceTransaction(context.Background(), validTx()); err != nil || !result.Allowed {
+		t.Fatalf("verified destination rejected: %+v, %v", result, err)
+	}
+
+	tx := validTx()
+	tx.Recipient = "mallory"
+	result, err := re.EnforceTransaction(context.Background(), tx)
+	if err != nil {
+		t.Fatalf("EnforceTransaction: %v", err)
+	}
+	if result.Allowed || !strings.Contains(result.Reason, ErrUnverifiedDestination.Error()) {
+		t.Fatalf("unverified destination: %+v; want rejection", result)
+	}
+
+	// Verifier errors fail closed.
+	re.SetDestinationVerifier(destStub{err: errors.New("registry down")})
+	result, err = re.EnforceTransaction(context.Background(), validTx())
+	if err != nil {
+		t.Fatalf("EnforceTransaction: %v", err)
+	}
+	if result.Allowed {
+		t.Fatal("verifier error admitted a transaction")
+	}
+}
+
+// geoStub resolves identities through a fixed country table.
+type geoStub struct {
+	countries map[string]string
+	err       error
+}
+
+func (g geoStub) Country(ctx context.Context, identity string) (string, error) {
+	return g.countries[identity], g.err
+}
+
+func TestGeoFence(t *testing.T) {
+	re := newTestEnforcer(KYCFull)
+	re.SetGeoFence(NewGeoFence(geoStub{countries: map[string]string{
+		"alice": "DE",
+		"eve":   "KP",
+	}}, "KP"))
+
+	if result, err := re.EnforceTransaction(context.Background(), validTx()); err != nil || !result.Allowed {
+		t.Fatalf("allowed-country origin rejected: %+v, %v", result, err)
+	}
+
+	tx := validTx()
+	tx.Origin = "eve"
+	result, err := re.EnforceTransaction(context.Background(), tx)
+	if err != nil {
+		t.Fatalf("EnforceTransaction: %v", err)
+	}
+	if result.Allowed || !strings.Contains(result.Reason, ErrGeoBlocked.Error()) {
+		t.Fatalf("blocked-country origin: %+v; want geo rejection", result)
+	}
+
+	// Resolver errors fail closed.
+	re.SetGeoFence(NewGeoFence(geoStub{err: errors.New("geoip down")}, "KP"))
+	result, err = re.EnforceTransaction(context.Background(), validTx())
+	if err != nil {
+		t.Fatalf("EnforceTransaction: %v", err)
+	}
+	if result.Allowed {
+		t.Fatal("resolver error admitted a transaction")
+	}
+}
+
+func TestSecondApprovalFlow(t *testing.T) {
+	re := newTestEnforcer(KYCFull)
+	re.SetSecondApprovalThreshold(1000)
+
+	below := validTx()
+	below.Amount = 500
+	if result, err := re.EnforceTransaction(context.Background(), below); err != nil || !result.Allowed {
+		t.Fatalf("below-threshold transaction: %+v, %v; want auto-accept", result, err)
+	}
+
+	above := validTx()
+	above.ID = "tx-big"
+	above.Amount = 5000
+	result, err := re.EnforceTransaction(context.Background(), above)
+	if err != nil {
+		t.Fatalf("EnforceTransaction: %v", err)
+	}
+	if result.Allowed || result.Reason != ReasonPendingApproval {
+		t.Fatalf("above-threshold transaction: %+v; want pending approval", result)
+	}
+	if !re.PendingApproval("tx-big") {
+		t.Fatal("transaction is not parked for approval")
+	}
+
+	// Self-approval is rejected and the hold stays in place.
+	if _, err := re.Approve("tx-big", "alice"); err == nil {
+		t.Fatal("originator approved their own transaction")
+	}
+	if !re.PendingApproval("tx-big") {
+		t.Fatal("rejected self-approval released the hold")
+	}
+
+	approved, err := re.Approve("tx-big", "carol")
+	if err != nil || !approved.Allowed {
+		t.Fatalf("Approve: %+v, %v; want allowed", approved, err)
+	}
+	if re.PendingApproval("tx-big") {
+		t.Fatal("approved transaction still pending")
+	}
+	if _, err := re.Approve("tx-big", "carol"); err == nil {
+		t.Fatal("double approval succeeded")
+	}
+}
+
+func TestEnforceBatchPreservesInputOrder(t *testing.T) {
+	registry := NewKYCRegistry()
+	registry.Register("SEC", kycStub{level: KYCFull})
+	re := NewRegulatoryEnforcer(registry)
+
+	txs := make([]Transaction, 40)
+	for i := range txs {
+		tx := validTx()
+		tx.ID = fmt.Sprintf("tx-%03d", i)
+		if i%3 == 0 {
+			tx.Jurisdiction = "MARS" // rejected as undetermined
+		}
+		txs[i] = tx
+	}
+
+	results := re.EnforceBatch(context.Background(), txs, 8)
+	if len(results) != len(txs) {
+		t.Fatalf("got %d results for %d transactions", len(results), len(txs))
+	}
+	for i, r := range results {
+		if r.Index != i {
+			t.Fatalf("results[%d].Index = %d", i, r.Index)
+		}
+		wantAllowed := i%3 != 0
+		if r.Err != nil || r.Result.Allowed != wantAllowed {
+			t.Fatalf("results[%d] = %+v, %v; want allowed=%v", i, r.Result, r.Err, wantAllowed)
+		}
+	}
+}
+
+func TestShadowEvaluatorRecordsDivergences(t *testing.T) {
+	SetRandSource(rand.NewSource(1)) // sample deterministically
+
+	active := &stubEnforcer{decide: func(tx Transaction) (EnforcementResult, error) {
+		return EnforcementResult{Allowed: true, Reason: "passed"}, nil
+	}}
+	shadow := &stubEnforcer{decide: func(tx Transaction) (EnforcementResult, error) {
+		if tx.Amount > 100 {
+			return EnforcementResult{Allowed: false, Reason: "candidate ceiling"}, nil
+		}
+		return EnforcementResult{Allowed: true, Reason: "passed"}, nil
+	}}
+	se := NewShadowEvaluator(active, shadow, 1.0)
+
+	small := validTx()
+	small.Amount = 50
+	big := validTx()
+	big.ID = "tx-2"
+	big.Amount = 500
+
+	// Live decisions always come from the active enforcer.
+	if result, err := se.EnforceTransaction(context.Background(), small); err != nil || !result.Allowed {
+		t.Fatalf("small tx: %+v, %v", result, err)
+	}
+	if result, err := se.EnforceTransaction(context.Background(), big); err != nil || !result.Allowed {
+		t.Fatalf("big tx shadow disagreement leaked into the live decision: %+v, %v", result, err)
+	}
+
+	if se.Sampled() != 2 {
+		t.Fatalf("Sampled() = %d, want 2 at fraction 1.0", se.Sampled())
+	}
+	div := se.Divergences()
+	if len(div) != 1 || div[0].TransactionID != "tx-2" || div[0].ShadowAllowed || !div[0].ActiveAllowed {
+		t.Fatalf("divergences = %+v; want one record for tx-2", div)
+	}
+}
+
+func TestFraudRuleStructuringTriggers(t *testing.T) {
+	fe := NewFraudRuleEngine(time.Hour)
+	fe.AddRule("round-number-structuring", RoundNumberStructuring(1000, 3))
+
+	now := time.Now().Unix()
+	for i := 0; i < 2; i++ {
+		tx := validTx()
+		tx.Amount = 9000
+		tx.Timestamp = now
+		if name, hit := fe.Check(tx); hit {
+			t.Fatalf("rule %q fired after only %d round transactions", name, i+1)
+		}
+	}
+	third := validTx()
+	third.Amount = 5000
+	third.Timestamp = now
+	name, hit := fe.Check(third)
+	if !hit || name != "round-number-structuring" {
+		t.Fatalf("third round transaction: (%q, %v); want structuring match", name, hit)
+	}
+}
+
+func TestFraudRuleBenignTrafficPasses(t *testing.T) {
+	fe := NewFraudRuleEngine(time.Hour)
+	fe.AddRule("round-number-structuring", RoundNumberStructuring(1000, 3))
+	fe.AddRule("rapid-recipient-switching", RapidRecipientSwitching(5))
+
+	now := time.Now().Unix()
+	for i := 0; i < 4; i++ {
+		tx := validTx()
+		tx.Amount = 137.42 + float64(i)
+		tx.Timestamp = now
+		if name, hit := fe.Check(tx); hit {
+			t.Fatalf("benign transaction %d matched rule %q", i, name)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package stablecoin
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Enforcer is anything that can decide a transaction; RegulatoryEnforcer is
+// the standard implementation.
+type Enforcer interface {
+	EnforceTransaction(ctx context.Context, tx Transaction) (EnforcementResult, error)
+}
+
+// EnforcerRouter dispatches each transaction to the enforcer configured for
+// its jurisdiction (an SEC deployment can run a stricter pipeline than an
+// IMF one), falling back to a default for unmapped jurisdictions.
+type EnforcerRouter struct {
+	mu       sync.RWMutex
+	routes   map[string]Enforcer
+	fallback Enforcer
+}
+
+func NewEnforcerRouter(fallback Enforcer) *EnforcerRouter {
+	return &EnforcerRouter{
+		routes:   make(map[string]Enforcer),
+		fallback: fallback,
+	}
+}
+
+// Route sends transactions for a jurisdiction to the given enforcer.
+func (r *EnforcerRouter) Route(jurisdiction string, e Enforcer) {
+	r.mu.Lock()
+	r.routes[jurisdiction] = e
+	r.mu.Unlock()
+}
+
+// EnforceTransaction routes by the transaction's jurisdiction.
+func (r *EnforcerRouter) EnforceTransaction(ctx context.Context, tx Transaction) (EnforcementResult, error) {
+	r.mu.RLock()
+	e, ok := r.routes[tx.Jurisdiction]
+	if !ok {
+		e = r.fallback
+	}
+	r.mu.RUnlock()
+	if e == nil {
+		return EnforcementResult{}, fmt.Errorf("no enforcer for jurisdiction %q and no fallback configured", tx.Jurisdiction)
+	}
+	return e.EnforceTransaction(ctx, tx)
+}
@@ -0,0 +1,59 @@
+package stablecoin
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// EnforcerStats is a point-in-time snapshot of enforcement counters.
+type EnforcerStats struct {
+	Total            uint64
+	Allowed          uint64
+	Rejected         uint64
+	RejectedByReason map[string]uint64
+}
+
+type enforcerCounters struct {
+	total   uint64
+	allowed uint64
+
+	mu       sync.Mutex
+	byReason map[string]uint64
+}
+
+func (c *enforcerCounters) record(result EnforcementResult) {
+	atomic.AddUint64(&c.total, 1)
+	if result.Allowed {
+		atomic.AddUint64(&c.allowed, 1)
+		return
+	}
+	c.mu.Lock()
+	if c.byReason == nil {
+		c.byReason = make(map[string]uint64)
+	}
+	c.byReason[result.Reason]++
+	c.mu.Unlock()
+}
+
+// Stats returns a snapshot of the enforcer's counters. It is cheap and
+// always current; no log scanning is involved.
+func (re *RegulatoryEnforcer) Stats() EnforcerStats {
+	total := atomic.LoadUint64(&re.counters.total)
+	allowed := atomic.LoadUint64(&re.counters.allowed)
+
+	re.counters.mu.Lock()
+	byReason := make(map[string]uint64, len(re.counters.byReason))
+	var rejected uint64
+	for reason, n := range re.counters.byReason {
+		byReason[reason] = n
+		rejected += n
+	}
+	re.counters.mu.Unlock()
+
+	return EnforcerStats{
+		Total:            total,
+		Allowed:          allowed,
+		Rejected:         rejected,
+		RejectedByReason: byReason,
+	}
+}
@@ -0,0 +1,346 @@
+package stablecoin
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func validTx() Transaction {
+	return Transaction{
+		ID: "tx-1", Origin: "alice", Recipient: "bob", Currency: "USD",
+		Amount: 10, Jurisdiction: "SEC",
+	}
+}
+
+func newTestEnforcer(level KYCLevel) *RegulatoryEnforcer {
+	registry := NewKYCRegistry()
+	registry.Register("SEC", kycStub{level: level})
+	return NewRegulatoryEnforcer(registry)
+}
+
+func TestEnforcePerJurisdictionProviders(t *testing.T) {
+	registry := NewKYCRegistry()
+	registry.Register("SEC", kycStub{level: KYCFull})
+	registry.Register("IMF", kycStub{level: KYCNone})
+	re := NewRegulatoryEnforcer(registry)
+
+	tx := validTx()
+	tx.Jurisdiction = "SEC"
+	result, err := re.EnforceTransaction(context.Background(), tx)
+	if err != nil || !result.Allowed {
+		t.Fatalf("SEC full-KYC transaction rejected: %+v, %v", result, err)
+	}
+
+	tx.Jurisdiction = "IMF"
+	result, err = re.EnforceTransaction(context.Background(), tx)
+	if err != nil {
+		t.Fatalf("EnforceTransaction: %v", err)
+	}
+	if result.Allowed {
+		t.Fatal("IMF none-KYC transaction allowed")
+	}
+}
+
+func TestEnforceFailsClosedOnProviderError(t *testing.T) {
+	registry := NewKYCRegistry()
+	registry.Register("SEC", kycStub{err: errors.New("provider unreachable")})
+	re := NewRegulatoryEnforcer(registry)
+
+	result, err := re.EnforceTransaction(context.Background(), validTx())
+	if err != nil {
+		t.Fatalf("EnforceTransaction: %v", err)
+	}
+	if result.Allowed {
+		t.Fatal("erroring provider admitted a transaction")
+	}
+}
+
+func TestPolicyModeResolvesUndetermined(t *testing.T) {
+	// No provider covers the jurisdiction, so the outcome is undetermined
+	// and the policy mode decides.
+	tx := validTx()
+	tx.Jurisdiction = "MARS"
+
+	re := newTestEnforcer(KYCFull)
+	result, err := re.EnforceTransaction(context.Background(), tx)
+	if err != nil {
+		t.Fatalf("EnforceTransaction: %v", err)
+	}
+	if result.Allowed {
+		t.Fatal("undetermined transaction allowed under DenyUnlessPass")
+	}
+
+	re.SetPolicyMode(AllowUnlessFail)
+	result, err = re.EnforceTransaction(context.Background(), tx)
+	if err != nil {
+		t.Fatalf("EnforceTransaction: %v", err)
+	}
+	if !result.Allowed {
+		t.Fatal("undetermined transaction rejected under AllowUnlessFail")
+	}
+}
+
+func TestLowKYCCeiling(t *testing.T) {
+	re := newTestEnforcer(KYCBasic)
+	re.SetLowKYCCeiling(100, nil)
+
+	below := validTx()
+	below.Amount = 50
+	result, err := re.EnforceTransaction(context.Background(), below)
+	if err != nil || !result.Allowed || !result.Monitored {
+		t.Fatalf("below-ceiling basic user: %+v, %v; want monitored allow", result, err)
+	}
+
+	above := validTx()
+	above.Amount = 500
+	result, err = re.EnforceTransaction(context.Background(), above)
+	if err != nil {
+		t.Fatalf("EnforceTransaction: %v", err)
+	}
+	if result.Allowed {
+		t.Fatal("above-ceiling basic user allowed")
+	}
+
+	full := newTestEnforcer(KYCFull)
+	full.SetLowKYCCeiling(100, nil)
+	result, err = full.EnforceTransaction(context.Background(), above)
+	if err != nil || !result.Allowed || result.Monitored {
+		t.Fatalf("full-KYC user above ceiling: %+v, %v; want unmonitored allow", result, err)
+	}
+}
+
+func TestTransactionExpiry(t *testing.T) {
+	re := newTestEnforcer(KYCFull)
+	now := time.Now().Unix()
+
+	expired := validTx()
+	expired.ValidUntil = now - 10
+	result, err := re.EnforceTransaction(context.Background(), expired)
+	if !errors.Is(err, ErrExpired) || result.Allowed {
+		t.Fatalf("expired transaction: %+v, %v; want ErrExpired rejection", result, err)
+	}
+
+	justValid := validTx()
+	justValid.ValidUntil = now + 60
+	if result, err := re.EnforceTransaction(context.Background(), justValid); err != nil || !result.Allowed {
+		t.Fatalf("still-valid transaction rejected: %+v, %v", result, err)
+	}
+
+	noExpiry := validTx()
+	if result, err := re.EnforceTransaction(context.Background(), noExpiry); err != nil || !result.Allowed {
+		t.Fatalf("no-expiry transaction rejected: %+v, %v", result, err)
+	}
+}
+
+func TestRequireJurisdiction(t *testing.T) {
+	re := newTestEnforcer(KYCFull)
+	missing := validTx()
+	missing.Jurisdiction = ""
+
+	// Off: the missing jurisdiction resolves through the policy mode.
+	re.SetPolicyMode(AllowUnlessFail)
+	if result, err := re.EnforceTransaction(context.Background(), missing); err != nil || !result.Allowed {
+		t.Fatalf("without requirement: %+v, %v; want allowed", result, err)
+	}
+
+	re.SetRequireJurisdiction(true)
+	result, err := re.EnforceTransaction(context.Background(), missing)
+	if !errors.Is(err, ErrMissingJurisdiction) || result.Allowed {
+		t.Fatalf("missing jurisdiction with requirement on: %+v, %v; want ErrMissingJurisdiction", result, err)
+	}
+
+	uncovered := validTx()
+	uncovered.Jurisdiction = "MARS"
+	result, err = re.EnforceTransaction(context.Background(), uncovered)
+	if !errors.Is(err, ErrMissingJurisdiction) || result.Allowed {
+		t.Fatalf("uncovered jurisdiction with requirement on: %+v, %v; want ErrMissingJurisdiction", result, err)
+	}
+}
+
+func TestAllowedCurrencies(t *testing.T) {
+	re := newTestEnforcer(KYCFull)
+	re.SetAllowedCurrencies("USD", "EUR")
+
+	if result, err := re.EnforceTransaction(context.Background(), validTx()); err != nil || !result.Allowed {
+		t.Fatalf("allowed currency rejected: %+v, %v", result, err)
+	}
+
+	tx := validTx()
+	tx.Currency = "DOGE"
+	result, err := re.EnforceTransaction(context.Background(), tx)
+	if err != nil {
+		t.Fatalf("EnforceTransaction: %v", err)
+	}
+	if result.Allowed || !strings.Contains(result.Reason, ErrCurrencyNotAllowed.Error()) {
+		t.Fatalf("disallowed currency: %+v; want currency rejection", result)
+	}
+
+	re.SetAllowedCurrencies()
+	if result, err := re.EnforceTransaction(context.Background(), tx); err != nil || !result.Allowed {
+		t.Fatalf("with allow-list disabled: %+v, %v; want allowed", result, err)
+	}
+}
+
+func TestStatsCountEveryDecision(t *testing.T) {
+	re := newTestEnforcer(KYCFull)
+
+	const workers, perWorker = 8, 50
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				tx := validTx()
+				if i%2 == 0 {
+					tx.Jurisdiction = "MARS" // undetermined -> rejected
+				}
+				re.EnforceTransaction(context.Background(), tx)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	stats := re.Stats()
+	if stats.Total != workers*perWorker {
+		t.Fatalf("Total = %d, want %d", stats.Total, workers*perWorker)
+	}
+	if stats.Allowed+stats.Rejected != stats.Total {
+		t.Fatalf("Allowed %d + Rejected %d != Total %d", stats.Allowed, stats.Rejected, stats.Total)
+	}
+}
+
+func TestStatsCountErrorSurfacedRejections(t *testing.T) {
+	re := newTestEnforcer(KYCFull)
+	expired := validTx()
+	expired.ValidUntil = time.Now().Unix() - 10
+	re.EnforceTransaction(context.Background(), expired)
+
+	stats := re.Stats()
+	if stats.Total != 1 || stats.RejectedByReason[ErrExpired.Error()] != 1 {
+		t.Fatalf("stats = %+v; expiry rejection was not counted", stats)
+	}
+}
+
+func TestTarpitDelaysRepeatOffenders(t *testing.T) {
+	tp := NewTarpit(time.Millisecond, 8*time.Millisecond, time.Minute)
+	if d := tp.Delay("clean"); d != 0 {
+		t.Fatalf("clean origin has delay %v", d)
+	}
+	var last time.Duration
+	for i := 0; i < 3; i++ {
+		tp.RecordRejection("abuser")
+		d := tp.Delay("abuser")
+		if d < last {
+			t.Fatalf("delay shrank from %v to %v", last, d)
+		}
+		last = d
+	}
+	for i := 0; i < 10; i++ {
+		tp.RecordRejection("abuser")
+	}
+	if d := tp.Delay("abuser"); d != 8*time.Millisecond {
+		t.Fatalf("delay %v exceeds cap", d)
+	}
+	if d := tp.Delay("clean"); d != 0 {
+		t.Fatalf("clean origin picked up delay %v", d)
+	}
+}
+
+func TestEnforcerRouterDispatchesByJurisdiction(t *testing.T) {
+	var secCalls, imfCalls int32
+	router := NewEnforcerRouter(&stubEnforcer{decide: func(tx Transaction) (EnforcementResult, error) {
+		return EnforcementResult{Allowed: false, Reason: "fallback"}, nil
+	}})
+	router.Route("SEC", &stubEnforcer{decide: func(tx Transaction) (EnforcementResult, error) {
+		atomic.AddInt32(&secCalls, 1)
+		return EnforcementResult{Allowed: true, Reason: "sec"}, nil
+	}})
+	router.Route("IMF", &stubEnforcer{decide: func(tx Transaction) (EnforcementResult, error) {
+		atomic.AddInt32(&imfCalls, 1)
+		return EnforcementResult{Allowed: true, Reason: "imf"}, nil
+	}})
+
+	tx := validTx()
+	tx.Jurisdiction = "SEC"
+	if result, _ := router.EnforceTransaction(context.Background(), tx); result.Reason != "sec" {
+		t.Fatalf("SEC routed to %q", result.Reason)
+	}
+	tx.Jurisdiction = "IMF"
+	if result, _ := router.EnforceTransaction(context.Background(), tx); result.Reason != "imf" {
+		t.Fatalf("IMF routed to %q", result.Reason)
+	}
+	tx.Jurisdiction = "MARS"
+	if result, _ := router.EnforceTransaction(context.Background(), tx); result.Reason != "fallback" {
+		t.Fatalf("unmapped jurisdiction routed to %q", result.Reason)
+	}
+	if secCalls != 1 || imfCalls != 1 {
+		t.Fatalf("calls sec=%d imf=%d, want 1 each", secCalls, imfCalls)
+	}
+}
+
+func TestCachingEnforcerSharesCanonicalDecisions(t *testing.T) {
+	var calls int32
+	ce := NewCachingEnforcer(&stubEnforcer{decide: func(tx Transaction) (EnforcementResult, error) {
+		atomic.AddInt32(&calls, 1)
+		return EnforcementResult{Allowed: true, Reason: "passed"}, nil
+	}}, time.Minute, 16, nil)
+
+	// The same transaction submitted twice — regardless of how its fields
+	// were ordered on the wire, the canonical hash is the key.
+	tx := validTx()
+	if _, err := ce.EnforceTransaction(context.Background(), tx); err != nil {
+		t.Fatalf("EnforceTransaction: %v", err)
+	}
+	if _, err := ce.EnforceTransaction(context.Background(), tx); err != nil {
+		t.Fatalf("EnforceTransaction: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("inner enforcer called %d times, want 1 (cache hit)", got)
+	}
+
+	other := validTx()
+	other.ID = "tx-2"
+	ce.EnforceTransaction(context.Background(), other)
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("distinct transaction did not miss the cache: %d calls", got)
+	}
+}
+
+func TestMaintenanceModeBlocksWritesNotReads(t *testing.T) {
+	ledger := NewLedger()
+	id, err := ledger.AddEntry("before maintenance")
+	if err != nil {
+		t.Fatalf("AddEntry: %v", err)
+	}
+
+	SetMaintenanceMode(true)
+	defer SetMaintenanceMode(false)
+
+	if _, err := ledger.AddEntry("during maintenance"); !errors.Is(err, ErrMaintenance) {
+		t.Fatalf("AddEntry during maintenance: %v, want ErrMaintenance", err)
+	}
+	if _, err := ledger.Entry(id); err != nil {
+		t.Fatalf("read during maintenance failed: %v", err)
+	}
+	if got := len(ledger.Entries()); got != 1 {
+		t.Fatalf("ledger has %d entries, want 1", got)
+	}
+}
+
+func TestEnforceProtoRoundTrip(t *testing.T) {
+	re := newTestEnforcer(KYCFull)
+	data := validTx().MarshalProto()
+	result, err := re.EnforceProto(context.Background(), data)
+	if err != nil || !result.Allowed {
+		t.Fatalf("proto-encoded transaction: %+v, %v; want allowed", result, err)
+	}
+	if _, err := re.EnforceProto(context.Background(), []byte{0xFF, 0xFF}); err == nil {
+		t.Fatal("malformed proto enforced without error")
+	}
+}
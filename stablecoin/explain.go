@@ -0,0 +1,98 @@
+package stablecoin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// TraceStep is one check in a decision trace.
+type TraceStep struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail"`
+}
+
+// DecisionTrace explains, step by step, why a transaction would be allowed
+// or rejected.
+type DecisionTrace struct {
+	TransactionID string      `json:"transactionId"`
+	Allowed       bool        `json:"allowed"`
+	Steps         []TraceStep `json:"steps"`
+}
+
+// Explain runs the enforcement checks against the transaction and records
+// the outcome of each step. It has no side effects: nothing is published,
+// counted or notified.
+func (re *RegulatoryEnforcer) Explain(ctx context.Context, tx Transaction) DecisionTrace {
+	trace := DecisionTrace{TransactionID: tx.ID}
+	step := func(name string, passed bool, detail string) bool {
+		trace.Steps = append(trace.Steps, TraceStep{Name: name, Passed: passed, Detail: detail})
+		return passed
+	}
+
+	if tx.ValidUntil != 0 && time.Now().Unix() > tx.ValidUntil {
+		step("expiry", false, ErrExpired.Error())
+		return trace
+	}
+	step("expiry", true, "not expired")
+
+	provider, err := re.kyc.Lookup(tx.Jurisdiction)
+	if err != nil {
+		resolved := re.resolveUndetermined(err.Error())
+		step("kyc-provider", resolved.Allowed, resolved.Reason)
+		trace.Allowed = resolved.Allowed
+		return trace
+	}
+	step("kyc-provider", true, "provider registered for "+tx.Jurisdiction)
+
+	verifyCtx := ctx
+	if re.kycTimeout > 0 {
+		var cancel context.CancelFunc
+		verifyCtx, cancel = context.WithTimeout(ctx, re.kycTimeout)
+		defer cancel()
+	}
+	level, err := provider.Verify(verifyCtx, tx.Origin, tx.Jurisdiction)
+	if err != nil {
+		step("kyc-verify", false, fmt.Sprintf("KYC verification failed: %v", err))
+		return trace
+	}
+	if !step("kyc-verify", level >= KYCBasic, fmt.Sprintf("KYC level %d", level)) {
+		return trace
+	}
+
+	if re.lowKYCCeiling > 0 && level < KYCFull {
+		if tx.Amount > re.lowKYCCeiling {
+			step("low-kyc-ceiling", false,
+				fmt.Sprintf("amount %g exceeds ceiling %g", tx.Amount, re.lowKYCCeiling))
+			return trace
+		}
+		step("low-kyc-ceiling", true, "within ceiling, monitored")
+	}
+
+	trace.Allowed = true
+	return trace
+}
+
+// ExplainTransactionHandler is the resolver behind the GraphQL
+// explainTransaction(input): DecisionTrace! query. It accepts a JSON
+// transaction and returns the trace.
+func ExplainTransactionHandler(re *RegulatoryEnforcer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var raw Transaction
+		if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+			http.Error(w, "invalid transaction input", http.StatusBadRequest)
+			return
+		}
+		tx, err := NormalizeAndValidate(raw)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		trace := re.Explain(r.Context(), tx)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(trace)
+	}
+}
@@ -0,0 +1,61 @@
+package stablecoin
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// TrainingSample is one structured observation captured for offline model
+// retraining.
+type TrainingSample struct {
+	Features  string    `json:"features"`
+	Label     string    `json:"label"`
+	Reward    float64   `json:"reward"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// FeatureStore durably captures training samples so learning survives
+// process restarts.
+type FeatureStore interface {
+	Record(sample TrainingSample) error
+	Export(w io.Writer) error
+}
+
+// FileFeatureStore appends samples to a JSONL file.
+type FileFeatureStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileFeatureStore(path string) *FileFeatureStore {
+	return &FileFeatureStore{path: path}
+}
+
+func (fs *FileFeatureStore) Record(sample TrainingSample) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	f, err := os.OpenFile(fs.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewEncoder(f).Encode(sample)
+}
+
+func (fs *FileFeatureStore) Export(w io.Writer) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	f, err := os.Open(fs.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
@@ -0,0 +1,45 @@
+package stablecoin
+
+import (
+	"log"
+	"strconv"
+	"strings"
+)
+
+// FeatureFormatVersion identifies the feature-string layout. Bump it when
+// the format below changes so recorded features remain interpretable.
+const FeatureFormatVersion = 1
+
+// DebugLog, when set, receives debug-level diagnostics such as the feature
+// string of rejected transactions.
+var DebugLog *log.Logger
+
+func debugf(format string, args ...interface{}) {
+	if DebugLog != nil {
+		DebugLog.Printf(format, args...)
+	}
+}
+
+// Features returns the exact, versioned feature string fed to models for
+// this transaction. Keeping construction in one place makes model inputs
+// reproducible when debugging misclassifications.
+func Features(tx Transaction) string {
+	parts := []string{
+		"v" + strconv.Itoa(FeatureFormatVersion),
+		"id=" + tx.ID,
+		"origin=" + tx.Origin,
+		"recipient=" + tx.Recipient,
+		"currency=" + tx.Currency,
+		"amount=" + strconv.FormatFloat(tx.Amount, 'g', -1, 64),
+		"jurisdiction=" + tx.Jurisdiction,
+		"timestamp=" + strconv.FormatInt(tx.Timestamp, 10),
+	}
+	return strings.Join(parts, "|")
+}
+
+// logRejectedFeatures records the model input of a rejected transaction at
+// debug level, with the redaction policy applied: the model saw the real
+// values, the log must not.
+func logRejectedFeatures(tx Transaction) {
+	debugf("transaction rejected; features: %s", Features(RedactTransaction(tx)))
+}
@@ -0,0 +1,95 @@
+package stablecoin
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// FraudPredicate inspects a sender's recent transactions (oldest first,
+// including the current one) and reports whether the pattern matches.
+type FraudPredicate func(history []Transaction) bool
+
+// FraudRule pairs a deterministic predicate with the name reported on
+// rejection.
+type FraudRule struct {
+	Name  string
+	Match FraudPredicate
+}
+
+// FraudRuleEngine runs deterministic fraud rules over a window of each
+// sender's recent transactions, independent of the ML model.
+type FraudRuleEngine struct {
+	window time.Duration
+
+	mu      sync.Mutex
+	rules   []FraudRule
+	history map[string][]Transaction // origin -> recent transactions
+}
+
+func NewFraudRuleEngine(window time.Duration) *FraudRuleEngine {
+	return &FraudRuleEngine{
+		window:  window,
+		history: make(map[string][]Transaction),
+	}
+}
+
+// AddRule registers a rule; rules are evaluated in registration order.
+func (fe *FraudRuleEngine) AddRule(name string, match FraudPredicate) {
+	fe.mu.Lock()
+	fe.rules = append(fe.rules, FraudRule{Name: name, Match: match})
+	fe.mu.Unlock()
+}
+
+// Check records the transaction in the sender's window and evaluates every
+// rule. It returns the name of the first matching rule, or "" if none
+// matched.
+func (fe *FraudRuleEngine) Check(tx Transaction) (string, bool) {
+	now := time.Now()
+	fe.mu.Lock()
+	defer fe.mu.Unlock()
+
+	recent := fe.history[tx.Origin][:0]
+	for _, h := range fe.history[tx.Origin] {
+		if now.Sub(time.Unix(h.Timestamp, 0)) <= fe.window {
+			recent = append(recent, h)
+		}
+	}
+	recent = append(recent, tx)
+	fe.history[tx.Origin] = recent
+
+	for _, rule := range fe.rules {
+		if rule.Match(recent) {
+			return rule.Name, true
+		}
+	}
+	return "", false
+}
+
+// RoundNumberStructuring matches when at least count transactions in the
+// window are exact multiples of step (classic structuring into round
+// amounts).
+func RoundNumberStructuring(step float64, count int) FraudPredicate {
+	return func(history []Transaction) bool {
+		n := 0
+		for _, tx := range history {
+			units := tx.Amount / step
+			if tx.Amount > 0 && math.Abs(units-math.Round(units)) < 1e-9 {
+				n++
+			}
+		}
+		return n >= count
+	}
+}
+
+// RapidRecipientSwitching matches when the sender hits at least count
+// distinct recipients within the window.
+func RapidRecipientSwitching(count int) FraudPredicate {
+	return func(history []Transaction) bool {
+		recipients := make(map[string]bool)
+		for _, tx := range history {
+			recipients[tx.Recipient] = true
+		}
+		return len(recipients) >= count
+	}
+}
@@ -0,0 +1,66 @@
+package stablecoin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrGeoBlocked is returned when a transaction originates from a blocked
+// country.
+var ErrGeoBlocked = errors.New("origin country is geo-blocked")
+
+// GeoResolver maps an identity to its ISO country code. Implementations
+// must be safe for concurrent use.
+type GeoResolver interface {
+	Country(ctx context.Context, identity string) (string, error)
+}
+
+// GeoFence rejects identities resolving to a blocked country. Resolver
+// errors fail closed: an identity whose country cannot be established is
+// treated as blocked.
+type GeoFence struct {
+	resolver GeoResolver
+
+	mu      sync.RWMutex
+	blocked map[string]bool
+}
+
+func NewGeoFence(resolver GeoResolver, blockedCountries ...string) *GeoFence {
+	gf := &GeoFence{resolver: resolver, blocked: make(map[string]bool)}
+	gf.Block(blockedCountries...)
+	return gf
+}
+
+// Block adds countries to the blocked set.
+func (gf *GeoFence) Block(countries ...string) {
+	gf.mu.Lock()
+	for _, c := range countries {
+		gf.blocked[strings.ToUpper(c)] = true
+	}
+	gf.mu.Unlock()
+}
+
+// Check returns nil when the identity's country is allowed.
+func (gf *GeoFence) Check(ctx context.Context, identity string) error {
+	country, err := gf.resolver.Country(ctx, identity)
+	if err != nil {
+		return fmt.Errorf("%w: resolving country for %s: %v", ErrGeoBlocked, identity, err)
+	}
+	gf.mu.RLock()
+	blocked := gf.blocked[strings.ToUpper(country)]
+	gf.mu.RUnlock()
+	if blocked {
+		return fmt.Errorf("%w: %s", ErrGeoBlocked, country)
+	}
+	return nil
+}
+
+// SetGeoFence rejects transactions from blocked countries ahead of every
+// other check, so blocked traffic never reaches KYC or model inference. Nil
+// disables geo-fencing.
+func (re *RegulatoryEnforcer) SetGeoFence(gf *GeoFence) {
+	re.geofence = gf
+}
@@ -0,0 +1,5 @@
+module github.com/KOSASIH/pi-supernode/stablecoin
+
+go 1.21
+
+require golang.org/x/text v0.14.0
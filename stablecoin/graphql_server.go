@@ -0,0 +1,176 @@
+package stablecoin
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GraphQLConfig controls the behaviour of the GraphQL HTTP servers.
+type GraphQLConfig struct {
+	// EnableIntrospection allows __schema/__type queries from anyone.
+	// Leave false in production.
+	EnableIntrospection bool
+	// IntrospectionTokens optionally allows introspection for callers
+	// presenting one of these bearer tokens even when
+	// EnableIntrospection is false.
+	IntrospectionTokens []string
+
+	// HTTP timeouts for the underlying server. Zero values fall back to
+	// the defaults below rather than "no timeout", so a misconfigured
+	// deployment is never open to slowloris.
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+}
+
+const (
+	defaultReadHeaderTimeout = 5 * time.Second
+	defaultReadTimeout       = 10 * time.Second
+	defaultWriteTimeout      = 30 * time.Second
+	defaultIdleTimeout       = 120 * time.Second
+)
+
+func orDefault(d, def time.Duration) time.Duration {
+	if d <= 0 {
+		return def
+	}
+	return d
+}
+
+// GraphQLServer wraps the query executor and applies server-level policy
+// such as the introspection gate.
+type GraphQLServer struct {
+	cfg  GraphQLConfig
+	next http.Handler
+
+	mu            sync.RWMutex
+	persisted     map[string]bool // sha256(query) -> registered
+	persistedOnly bool
+}
+
+func NewGraphQLServer(cfg GraphQLConfig, executor http.Handler) *GraphQLServer {
+	return &GraphQLServer{
+		cfg:       cfg,
+		next:      executor,
+		persisted: make(map[string]bool),
+	}
+}
+
+func queryHash(query []byte) string {
+	sum := sha256.Sum256(query)
+	return hex.EncodeToString(sum[:])
+}
+
+// RegisterPersistedQuery adds a query to the allow-list and returns its
+// hash.
+func (s *GraphQLServer) RegisterPersistedQuery(query string) string {
+	hash := queryHash([]byte(query))
+	s.mu.Lock()
+	s.persisted[hash] = true
+	s.mu.Unlock()
+	return hash
+}
+
+// SetPersistedOnly toggles allow-list enforcement. When on, only registered
+// persisted queries are executed; ad-hoc queries get 403.
+func (s *GraphQLServer) SetPersistedOnly(on bool) {
+	s.mu.Lock()
+	s.persistedOnly = on
+	s.mu.Unlock()
+}
+
+// graphqlEnvelope is the JSON body of a standard GraphQL POST.
+type graphqlEnvelope struct {
+	Query string `json:"query"`
+}
+
+// extractQuery pulls the query string out of the request body: the JSON
+// envelope's query field when the body is an envelope, otherwise the raw
+// body (application/graphql style).
+func extractQuery(body []byte) string {
+	var env graphqlEnvelope
+	if err := json.Unmarshal(body, &env); err == nil && env.Query != "" {
+		return env.Query
+	}
+	return string(body)
+}
+
+func (s *GraphQLServer) persistedAllowed(query string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.persistedOnly {
+		return true
+	}
+	return s.persisted[queryHash([]byte(query))]
+}
+
+// introspectionField matches the __schema/__type meta-fields as whole
+// words, so __typename — which ordinary clients inject into every query —
+// and occurrences inside longer identifiers do not count as introspection.
+var introspectionField = regexp.MustCompile(`__(schema|type)\b`)
+
+func isIntrospectionQuery(query string) bool {
+	return introspectionField.MatchString(query)
+}
+
+func (s *GraphQLServer) introspectionAllowed(r *http.Request) bool {
+	if s.cfg.EnableIntrospection {
+		return true
+	}
+	auth := r.Header.Get("Authorization")
+	token := strings.TrimPrefix(auth, "Bearer ")
+	for _, t := range s.cfg.IntrospectionTokens {
+		if token != "" && token == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *GraphQLServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "reading request body", http.StatusBadRequest)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	query := extractQuery(body)
+	if isIntrospectionQuery(query) && !s.introspectionAllowed(r) {
+		http.Error(w, "introspection is disabled", http.StatusForbidden)
+		return
+	}
+	if !s.persistedAllowed(query) {
+		http.Error(w, "only persisted queries are accepted", http.StatusForbidden)
+		return
+	}
+	s.next.ServeHTTP(w, r)
+}
+
+// HTTPServer builds an explicit http.Server for the GraphQL endpoint with
+// the configured timeouts applied, instead of the timeout-less server that
+// http.ListenAndServe would use.
+func (s *GraphQLServer) HTTPServer(addr string) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           s,
+		ReadHeaderTimeout: orDefault(s.cfg.ReadHeaderTimeout, defaultReadHeaderTimeout),
+		ReadTimeout:       orDefault(s.cfg.ReadTimeout, defaultReadTimeout),
+		WriteTimeout:      orDefault(s.cfg.WriteTimeout, defaultWriteTimeout),
+		IdleTimeout:       orDefault(s.cfg.IdleTimeout, defaultIdleTimeout),
+	}
+}
+
+// ListenAndServe runs the GraphQL server on addr with timeouts enforced.
+func (s *GraphQLServer) ListenAndServe(addr string) error {
+	return s.HTTPServer(addr).ListenAndServe()
+}
@@ -0,0 +1,253 @@
+package stablecoin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// kycStub returns a fixed level (or error) regardless of user; enforcer and
+// resolver tests register it per jurisdiction.
+type kycStub struct {
+	level KYCLevel
+	err   error
+}
+
+func (s kycStub) Verify(ctx context.Context, userID, jurisdiction string) (KYCLevel, error) {
+	return s.level, s.err
+}
+
+func okExecutor() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func postQuery(t *testing.T, s *GraphQLServer, body string, headers map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIntrospectionBlockedWhenDisabled(t *testing.T) {
+	s := NewGraphQLServer(GraphQLConfig{}, okExecutor())
+	rec := postQuery(t, s, `{"query":"query { __schema { types { name } } }"}`, nil)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("introspection query got %d, want 403", rec.Code)
+	}
+	rec = postQuery(t, s, `{"query":"query { __type(name: \"Transaction\") { name } }"}`, nil)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("__type query got %d, want 403", rec.Code)
+	}
+}
+
+func TestIntrospectionAllowedWhenEnabledOrTokened(t *testing.T) {
+	enabled := NewGraphQLServer(GraphQLConfig{EnableIntrospection: true}, okExecutor())
+	rec := postQuery(t, enabled, `{"query":"{ __schema { types { name } } }"}`, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("introspection with EnableIntrospection got %d, want 200", rec.Code)
+	}
+
+	tokened := NewGraphQLServer(GraphQLConfig{IntrospectionTokens: []string{"ops-token"}}, okExecutor())
+	rec = postQuery(t, tokened, `{"query":"{ __schema { types { name } } }"}`,
+		map[string]string{"Authorization": "Bearer ops-token"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("introspection with valid token got %d, want 200", rec.Code)
+	}
+	rec = postQuery(t, tokened, `{"query":"{ __schema { types { name } } }"}`,
+		map[string]string{"Authorization": "Bearer wrong"})
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("introspection with wrong token got %d, want 403", rec.Code)
+	}
+}
+
+func TestTypenameIsNotTreatedAsIntrospection(t *testing.T) {
+	s := NewGraphQLServer(GraphQLConfig{}, okExecutor())
+	rec := postQuery(t, s, `{"query":"query { stablecoinData { __typename value } }"}`, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("__typename query got %d, want 200: clients inject it into every query", rec.Code)
+	}
+}
+
+func TestPersistedQueryAllowListMatchesEnvelope(t *testing.T) {
+	s := NewGraphQLServer(GraphQLConfig{}, okExecutor())
+	const query = "query { stablecoinData { value } }"
+	s.RegisterPersistedQuery(query)
+	s.SetPersistedOnly(true)
+
+	// A standard client wraps the registered query in a JSON envelope: the
+	// allow-list must match the query field, not the whole body.
+	envelope, _ := json.Marshal(map[string]string{"query": query})
+	rec := postQuery(t, s, string(envelope), nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("registered persisted query got %d, want 200", rec.Code)
+	}
+
+	// The bare application/graphql form must match too.
+	rec = postQuery(t, s, query, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("bare registered query got %d, want 200", rec.Code)
+	}
+
+	rec = postQuery(t, s, `{"query":"query { somethingElse }"}`, nil)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("ad-hoc query got %d, want 403", rec.Code)
+	}
+
+	s.SetPersistedOnly(false)
+	rec = postQuery(t, s, `{"query":"query { somethingElse }"}`, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("ad-hoc query with enforcement off got %d, want 200", rec.Code)
+	}
+}
+
+func TestHTTPServerAppliesTimeouts(t *testing.T) {
+	// Zero config falls back to defaults rather than "no timeout".
+	srv := NewGraphQLServer(GraphQLConfig{}, okExecutor()).HTTPServer(":0")
+	if srv.ReadHeaderTimeout != defaultReadHeaderTimeout || srv.IdleTimeout != defaultIdleTimeout {
+		t.Fatalf("zero config did not fall back to defaults: %+v", srv)
+	}
+	srv = NewGraphQLServer(GraphQLConfig{ReadHeaderTimeout: time.Second}, okExecutor()).HTTPServer(":0")
+	if srv.ReadHeaderTimeout != time.Second {
+		t.Fatalf("configured ReadHeaderTimeout not applied: %v", srv.ReadHeaderTimeout)
+	}
+}
+
+func TestResolverLimiterShedsExcessLoad(t *testing.T) {
+	rl := NewResolverLimiter(2, 20*time.Millisecond)
+	release := make(chan struct{})
+	var busy, served int32
+
+	handler := rl.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&served, 1)
+		<-release
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", nil))
+			if rec.Code == http.StatusServiceUnavailable {
+				atomic.AddInt32(&busy, 1)
+			}
+		}()
+	}
+	// Let the first two occupy slots, then unblock them once the rest have
+	// timed out gracefully.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&served); got != 2 {
+		t.Fatalf("%d requests ran concurrently, want 2", got)
+	}
+	if got := atomic.LoadInt32(&busy); got != 3 {
+		t.Fatalf("%d requests were shed, want 3", got)
+	}
+}
+
+func TestExplainTransactionHandlerTracesFailingCheck(t *testing.T) {
+	registry := NewKYCRegistry()
+	registry.Register("SEC", kycStub{level: KYCNone})
+	re := NewRegulatoryEnforcer(registry)
+
+	body, _ := json.Marshal(Transaction{
+		ID: "tx-1", Origin: "alice", Recipient: "bob", Currency: "USD",
+		Amount: 5, Jurisdiction: "SEC",
+	})
+	rec := httptest.NewRecorder()
+	ExplainTransactionHandler(re)(rec, httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("explain handler got %d: %s", rec.Code, rec.Body)
+	}
+	var trace DecisionTrace
+	if err := json.Unmarshal(rec.Body.Bytes(), &trace); err != nil {
+		t.Fatalf("decoding trace: %v", err)
+	}
+	if trace.Allowed {
+		t.Fatal("trace reports allowed for a KYCNone user")
+	}
+	var failed *TraceStep
+	for i := range trace.Steps {
+		if !trace.Steps[i].Passed {
+			failed = &trace.Steps[i]
+			break
+		}
+	}
+	if failed == nil || failed.Name != "kyc-verify" {
+		t.Fatalf("trace does not blame kyc-verify: %+v", trace.Steps)
+	}
+}
+
+func TestVersionHandlerReturnsInjectedBuildVars(t *testing.T) {
+	oldVersion, oldCommit := Version, GitCommit
+	Version, GitCommit = "v1.2.3", "abc1234"
+	defer func() { Version, GitCommit = oldVersion, oldCommit }()
+
+	rec := httptest.NewRecorder()
+	VersionHandler()(rec, httptest.NewRequest(http.MethodGet, "/version", nil))
+	var info BuildInfo
+	if err := json.Unmarshal(rec.Body.Bytes(), &info); err != nil {
+		t.Fatalf("decoding build info: %v", err)
+	}
+	if info.Version != "v1.2.3" || info.GitCommit != "abc1234" {
+		t.Fatalf("build info = %+v, want injected vars", info)
+	}
+}
+
+func TestLedgerLoaderBatchesLookups(t *testing.T) {
+	ledger := NewLedger()
+	ids := make([]int64, 5)
+	for i := range ids {
+		id, err := ledger.AddEntry("entry " + string(rune('a'+i)))
+		if err != nil {
+			t.Fatalf("AddEntry: %v", err)
+		}
+		ids[i] = id
+	}
+
+	var calls int32
+	loader := NewLedgerLoader(func(batch []int64) (map[int64]LedgerEntry, error) {
+		atomic.AddInt32(&calls, 1)
+		return ledger.BatchEntries(batch)
+	}, 10*time.Millisecond, 100)
+
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id int64) {
+			defer wg.Done()
+			entry, err := loader.Load(id)
+			if err != nil {
+				t.Errorf("Load(%d): %v", id, err)
+				return
+			}
+			if entry.ID != id {
+				t.Errorf("Load(%d) returned entry %d", id, entry.ID)
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("store was hit %d times for one resolution tick, want 1", got)
+	}
+	if _, err := loader.Load(99999); err == nil {
+		t.Fatal("Load of a missing entry returned no error")
+	}
+}
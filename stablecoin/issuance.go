@@ -0,0 +1,165 @@
+package stablecoin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// Typed issuance failures, so clients can distinguish retryable conditions
+// (oracle hiccups) from permanent ones (unknown type) via errors.Is.
+var (
+	ErrOracleRejected    = errors.New("issuance rejected by oracle")
+	ErrUnknownStablecoin = errors.New("unknown stablecoin type")
+	ErrInsufficientPool  = errors.New("insufficient reserve pool")
+)
+
+// IssuanceOracle approves or rejects a proposed issuance. A nil error means
+// the issuance may proceed.
+type IssuanceOracle interface {
+	ApproveIssuance(ctx context.Context, stablecoin, recipient string, amount float64) error
+}
+
+// IssuanceEngine mints stablecoin against per-type reserve pools.
+type IssuanceEngine struct {
+	mu           sync.RWMutex
+	pools        map[string]float64 // stablecoin type -> remaining pool
+	issued       map[string]float64 // recipient -> total issued
+	oracle       IssuanceOracle
+	smallestUnit float64 // granularity of issuance, e.g. 0.01 for cents
+
+	holds   map[string]poolHold // open reservations by hold id
+	holdTTL time.Duration
+
+	schema *IssuanceSchema // nil skips structural request validation
+}
+
+func NewIssuanceEngine() *IssuanceEngine {
+	return &IssuanceEngine{
+		pools:        make(map[string]float64),
+		issued:       make(map[string]float64),
+		smallestUnit: 0.01,
+	}
+}
+
+// SetSmallestUnit configures the minor unit of issuance. Requests finer
+// than this granularity are rejected. A unit of 1 restores whole-coin
+// issuance.
+func (ie *IssuanceEngine) SetSmallestUnit(unit float64) {
+	ie.mu.Lock()
+	ie.smallestUnit = unit
+	ie.mu.Unlock()
+}
+
+// checkGranularity rejects amounts that are not a whole multiple of the
+// smallest unit.
+func (ie *IssuanceEngine) checkGranularity(amount float64) error {
+	ie.mu.RLock()
+	unit := ie.smallestUnit
+	ie.mu.RUnlock()
+	if unit <= 0 {
+		return nil
+	}
+	units := amount / unit
+	if math.Abs(units-math.Round(units)) > 1e-9 {
+		return fmt.Errorf("amount %g is finer than the smallest unit %g", amount, unit)
+	}
+	return nil
+}
+
+// SetOracle installs the oracle consulted before every issuance.
+func (ie *IssuanceEngine) SetOracle(o IssuanceOracle) {
+	ie.mu.Lock()
+	ie.oracle = o
+	ie.mu.Unlock()
+}
+
+// ErrAmountOverflow is returned when a deposit or issuance would push a
+// balance past what the money type can represent.
+var ErrAmountOverflow = errors.New("amount overflows reserve balance")
+
+// Deposit credits the reserve pool for a stablecoin type, creating it if
+// needed. The arithmetic is checked: a deposit that would overflow the
+// balance is rejected, and the pool is only mutated under the lock so
+// concurrent deposits and issuances always total correctly.
+func (ie *IssuanceEngine) Deposit(stablecoin string, amount float64) error {
+	if err := checkMaintenance(); err != nil {
+		return err
+	}
+	if amount <= 0 || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return fmt.Errorf("deposit amount must be a positive finite number, got %g", amount)
+	}
+	ie.mu.Lock()
+	defer ie.mu.Unlock()
+	next := ie.pools[stablecoin] + amount
+	if math.IsInf(next, 0) || next < ie.pools[stablecoin] {
+		return fmt.Errorf("%w: %g + %g", ErrAmountOverflow, ie.pools[stablecoin], amount)
+	}
+	ie.pools[stablecoin] = next
+	return nil
+}
+
+// AddPool is the historical name for Deposit, kept for callers that predate
+// checked deposits.
+func (ie *IssuanceEngine) AddPool(stablecoin string, amount float64) {
+	ie.Deposit(stablecoin, amount)
+}
+
+// IssueStablecoin mints amount of the given stablecoin type to recipient.
+// Failures wrap ErrOracleRejected, ErrUnknownStablecoin or
+// ErrInsufficientPool.
+func (ie *IssuanceEngine) IssueStablecoin(ctx context.Context, stablecoin, recipient string, amount float64) error {
+	if err := checkMaintenance(); err != nil {
+		return err
+	}
+	if recipient == "" {
+		return fmt.Errorf("recipient is required")
+	}
+	if amount <= 0 || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return fmt.Errorf("amount must be a positive finite number, got %g", amount)
+	}
+	if err := ie.checkGranularity(amount); err != nil {
+		return err
+	}
+
+	ie.mu.RLock()
+	oracle := ie.oracle
+	ie.mu.RUnlock()
+	if oracle != nil {
+		if err := oracle.ApproveIssuance(ctx, stablecoin, recipient, amount); err != nil {
+			return fmt.Errorf("%w: %v", ErrOracleRejected, err)
+		}
+	}
+
+	ie.mu.Lock()
+	defer ie.mu.Unlock()
+	ie.expireHolds(time.Now())
+	pool, ok := ie.pools[stablecoin]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownStablecoin, stablecoin)
+	}
+	// Capacity claimed by open holds is not available to direct issuance.
+	if available := pool - ie.reservedFor(stablecoin); available < amount {
+		return fmt.Errorf("%w: have %g, need %g", ErrInsufficientPool, available, amount)
+	}
+	ie.pools[stablecoin] = pool - amount
+	ie.issued[recipient] += amount
+	return nil
+}
+
+// IssuedTo reports the total amount issued to a recipient.
+func (ie *IssuanceEngine) IssuedTo(recipient string) float64 {
+	ie.mu.RLock()
+	defer ie.mu.RUnlock()
+	return ie.issued[recipient]
+}
+
+// Pool reports the remaining reserve pool for a stablecoin type.
+func (ie *IssuanceEngine) Pool(stablecoin string) float64 {
+	ie.mu.RLock()
+	defer ie.mu.RUnlock()
+	return ie.pools[stablecoin]
+}
@@ -0,0 +1,128 @@
+package stablecoin
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// ErrUnknownHold is returned when committing or releasing a hold that does
+// not exist, already settled, or expired.
+var ErrUnknownHold = errors.New("unknown or expired hold")
+
+// poolHold is one open reservation against a stablecoin pool.
+type poolHold struct {
+	stablecoin string
+	amount     float64
+	expiresAt  time.Time
+}
+
+var holdCounter uint64
+
+// SetHoldTTL sets how long an uncommitted reservation stays valid before it
+// auto-releases. The default is one minute.
+func (ie *IssuanceEngine) SetHoldTTL(ttl time.Duration) {
+	ie.mu.Lock()
+	ie.holdTTL = ttl
+	ie.mu.Unlock()
+}
+
+// expireHolds drops lapsed reservations, returning their capacity to the
+// pool. Callers hold ie.mu.
+func (ie *IssuanceEngine) expireHolds(now time.Time) {
+	for id, h := range ie.holds {
+		if now.After(h.expiresAt) {
+			delete(ie.holds, id)
+		}
+	}
+}
+
+// reservedFor sums open holds against one pool. Callers hold ie.mu.
+func (ie *IssuanceEngine) reservedFor(stablecoin string) float64 {
+	var total float64
+	for _, h := range ie.holds {
+		if h.stablecoin == stablecoin {
+			total += h.amount
+		}
+	}
+	return total
+}
+
+// Reserve places a hold on pool capacity for a multi-step flow: the
+// capacity stops being available to other issuances immediately, but the
+// pool balance itself only moves on Commit. Abandoned holds auto-release
+// after the TTL.
+func (ie *IssuanceEngine) Reserve(stablecoin string, amount float64) (string, error) {
+	if err := checkMaintenance(); err != nil {
+		return "", err
+	}
+	if amount <= 0 {
+		return "", fmt.Errorf("reservation amount must be positive, got %g", amount)
+	}
+
+	ie.mu.Lock()
+	defer ie.mu.Unlock()
+	now := time.Now()
+	ie.expireHolds(now)
+
+	pool, ok := ie.pools[stablecoin]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownStablecoin, stablecoin)
+	}
+	available := pool - ie.reservedFor(stablecoin)
+	if available < amount {
+		return "", fmt.Errorf("%w: %g available after holds, need %g", ErrInsufficientPool, available, amount)
+	}
+
+	ttl := ie.holdTTL
+	if ttl <= 0 {
+		ttl = time.Minute
+	}
+	id := "hold-" + strconv.FormatUint(atomic.AddUint64(&holdCounter, 1), 10)
+	if ie.holds == nil {
+		ie.holds = make(map[string]poolHold)
+	}
+	ie.holds[id] = poolHold{stablecoin: stablecoin, amount: amount, expiresAt: now.Add(ttl)}
+	return id, nil
+}
+
+// Commit settles a hold, decrementing the real pool balance.
+func (ie *IssuanceEngine) Commit(holdID string) error {
+	if err := checkMaintenance(); err != nil {
+		return err
+	}
+	ie.mu.Lock()
+	defer ie.mu.Unlock()
+	ie.expireHolds(time.Now())
+
+	h, ok := ie.holds[holdID]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownHold, holdID)
+	}
+	delete(ie.holds, holdID)
+	ie.pools[h.stablecoin] -= h.amount
+	return nil
+}
+
+// Release cancels a hold, returning its capacity to the pool.
+func (ie *IssuanceEngine) Release(holdID string) error {
+	ie.mu.Lock()
+	defer ie.mu.Unlock()
+	ie.expireHolds(time.Now())
+
+	if _, ok := ie.holds[holdID]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownHold, holdID)
+	}
+	delete(ie.holds, holdID)
+	return nil
+}
+
+// Available reports the pool capacity not claimed by open holds.
+func (ie *IssuanceEngine) Available(stablecoin string) float64 {
+	ie.mu.Lock()
+	defer ie.mu.Unlock()
+	ie.expireHolds(time.Now())
+	return ie.pools[stablecoin] - ie.reservedFor(stablecoin)
+}
@@ -0,0 +1,106 @@
+package stablecoin
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// IssuanceRequest is the structured form of an issuance submission, decoded
+// from the free-form requests the oracle used to receive directly.
+type IssuanceRequest struct {
+	Stablecoin string  `json:"stablecoin"`
+	Recipient  string  `json:"recipient"`
+	Amount     float64 `json:"amount"`
+	Reference  string  `json:"reference,omitempty"`
+}
+
+// FieldError names the exact field a schema violation concerns.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("field %s: %s", e.Field, e.Message)
+}
+
+// IssuanceSchema validates requests structurally before the oracle is
+// consulted. Zero-valued constraints are not enforced.
+type IssuanceSchema struct {
+	AllowedStablecoins []string       // empty allows any
+	RecipientPattern   *regexp.Regexp // nil allows any non-empty recipient
+	MinAmount          float64
+	MaxAmount          float64
+	MaxReferenceLength int
+}
+
+// Validate checks the request against the schema, collecting every field
+// violation rather than stopping at the first.
+func (s IssuanceSchema) Validate(req IssuanceRequest) error {
+	var violations []string
+
+	if req.Stablecoin == "" {
+		violations = append(violations, FieldError{"stablecoin", "required"}.Error())
+	} else if len(s.AllowedStablecoins) > 0 {
+		allowed := false
+		for _, a := range s.AllowedStablecoins {
+			if a == req.Stablecoin {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			violations = append(violations, FieldError{"stablecoin", fmt.Sprintf("%q is not an allowed type", req.Stablecoin)}.Error())
+		}
+	}
+
+	if req.Recipient == "" {
+		violations = append(violations, FieldError{"recipient", "required"}.Error())
+	} else if s.RecipientPattern != nil && !s.RecipientPattern.MatchString(req.Recipient) {
+		violations = append(violations, FieldError{"recipient", fmt.Sprintf("%q does not match %s", req.Recipient, s.RecipientPattern)}.Error())
+	}
+
+	switch {
+	case math.IsNaN(req.Amount) || math.IsInf(req.Amount, 0) || req.Amount <= 0:
+		violations = append(violations, FieldError{"amount", fmt.Sprintf("must be a positive finite number, got %g", req.Amount)}.Error())
+	case s.MinAmount > 0 && req.Amount < s.MinAmount:
+		violations = append(violations, FieldError{"amount", fmt.Sprintf("%g below minimum %g", req.Amount, s.MinAmount)}.Error())
+	case s.MaxAmount > 0 && req.Amount > s.MaxAmount:
+		violations = append(violations, FieldError{"amount", fmt.Sprintf("%g above maximum %g", req.Amount, s.MaxAmount)}.Error())
+	}
+
+	if s.MaxReferenceLength > 0 && len(req.Reference) > s.MaxReferenceLength {
+		violations = append(violations, FieldError{"reference", fmt.Sprintf("%d bytes exceeds limit %d", len(req.Reference), s.MaxReferenceLength)}.Error())
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("invalid issuance request: %s", strings.Join(violations, "; "))
+	}
+	return nil
+}
+
+// SetRequestSchema installs the schema applied by IssueRequest before the
+// oracle sees anything. Nil disables structural validation.
+func (ie *IssuanceEngine) SetRequestSchema(schema *IssuanceSchema) {
+	ie.mu.Lock()
+	ie.schema = schema
+	ie.mu.Unlock()
+}
+
+// IssueRequest validates the structured request against the configured
+// schema and then runs the normal issuance path, so malformed submissions
+// are rejected with precise field errors before the oracle is consulted.
+func (ie *IssuanceEngine) IssueRequest(ctx context.Context, req IssuanceRequest) error {
+	ie.mu.RLock()
+	schema := ie.schema
+	ie.mu.RUnlock()
+	if schema != nil {
+		if err := schema.Validate(req); err != nil {
+			return err
+		}
+	}
+	return ie.IssueStablecoin(ctx, req.Stablecoin, req.Recipient, req.Amount)
+}
@@ -0,0 +1,332 @@
+package stablecoin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// oracleStub approves or rejects every issuance and counts consultations.
+type oracleStub struct {
+	err   error
+	calls int32
+}
+
+func (o *oracleStub) ApproveIssuance(ctx context.Context, stablecoin, recipient string, amount float64) error {
+	atomic.AddInt32(&o.calls, 1)
+	return o.err
+}
+
+func TestIssuanceTypedFailures(t *testing.T) {
+	ie := NewIssuanceEngine()
+	ie.Deposit("USDC", 100)
+
+	if err := ie.IssueStablecoin(context.Background(), "DOGE", "alice", 10); !errors.Is(err, ErrUnknownStablecoin) {
+		t.Fatalf("unknown type: %v, want ErrUnknownStablecoin", err)
+	}
+	if err := ie.IssueStablecoin(context.Background(), "USDC", "alice", 500); !errors.Is(err, ErrInsufficientPool) {
+		t.Fatalf("over-pool issuance: %v, want ErrInsufficientPool", err)
+	}
+
+	ie.SetOracle(&oracleStub{err: errors.New("rate anomaly")})
+	if err := ie.IssueStablecoin(context.Background(), "USDC", "alice", 10); !errors.Is(err, ErrOracleRejected) {
+		t.Fatalf("oracle rejection: %v, want ErrOracleRejected", err)
+	}
+
+	ie.SetOracle(nil)
+	if err := ie.IssueStablecoin(context.Background(), "USDC", "alice", 10); err != nil {
+		t.Fatalf("valid issuance failed: %v", err)
+	}
+	if got := ie.IssuedTo("alice"); got != 10 {
+		t.Fatalf("IssuedTo = %g, want 10", got)
+	}
+}
+
+func TestConcurrentDepositsAndIssuances(t *testing.T) {
+	ie := NewIssuanceEngine()
+	ie.Deposit("USDC", 1000)
+
+	const workers, perWorker = 8, 25
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				ie.Deposit("USDC", 2)
+				ie.IssueStablecoin(context.Background(), "USDC", "alice", 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 1000 + 200 workers*perWorker*2 deposited, workers*perWorker issued.
+	want := 1000.0 + float64(workers*perWorker)*2 - float64(workers*perWorker)
+	if got := ie.Pool("USDC"); got != want {
+		t.Fatalf("pool = %g, want exactly %g", got, want)
+	}
+	if got := ie.IssuedTo("alice"); got != float64(workers*perWorker) {
+		t.Fatalf("issued = %g, want %g", got, float64(workers*perWorker))
+	}
+}
+
+func TestDepositValidation(t *testing.T) {
+	ie := NewIssuanceEngine()
+	for _, amount := range []float64{0, -5} {
+		if err := ie.Deposit("USDC", amount); err == nil {
+			t.Errorf("deposit of %g accepted", amount)
+		}
+	}
+}
+
+func TestIssuanceGranularity(t *testing.T) {
+	ie := NewIssuanceEngine()
+	ie.Deposit("USDC", 100)
+
+	// Default smallest unit is 0.01: sub-cent requests are rejected.
+	if err := ie.IssueStablecoin(context.Background(), "USDC", "alice", 0.001); err == nil {
+		t.Fatal("sub-granular issuance accepted")
+	}
+	if err := ie.IssueStablecoin(context.Background(), "USDC", "alice", 0.25); err != nil {
+		t.Fatalf("sub-unit (but granular) issuance failed: %v", err)
+	}
+
+	ie.SetSmallestUnit(1)
+	if err := ie.IssueStablecoin(context.Background(), "USDC", "alice", 0.5); err == nil {
+		t.Fatal("fractional issuance accepted with whole-coin granularity")
+	}
+}
+
+func TestPoolHoldsReserveCommitRelease(t *testing.T) {
+	ie := NewIssuanceEngine()
+	ie.Deposit("USDC", 100)
+
+	hold, err := ie.Reserve("USDC", 60)
+	if err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+	if got := ie.Available("USDC"); got != 40 {
+		t.Fatalf("available after hold = %g, want 40", got)
+	}
+	// Held capacity is not available to direct issuance.
+	if err := ie.IssueStablecoin(context.Background(), "USDC", "alice", 50); !errors.Is(err, ErrInsufficientPool) {
+		t.Fatalf("issuance into held capacity: %v, want ErrInsufficientPool", err)
+	}
+
+	if err := ie.Commit(hold); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if got := ie.Pool("USDC"); got != 40 {
+		t.Fatalf("pool after commit = %g, want 40", got)
+	}
+	if err := ie.Commit(hold); !errors.Is(err, ErrUnknownHold) {
+		t.Fatalf("double commit: %v, want ErrUnknownHold", err)
+	}
+
+	hold2, _ := ie.Reserve("USDC", 10)
+	if err := ie.Release(hold2); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if got := ie.Available("USDC"); got != 40 {
+		t.Fatalf("available after release = %g, want 40", got)
+	}
+}
+
+func TestConcurrentReservationsAccountExactly(t *testing.T) {
+	ie := NewIssuanceEngine()
+	ie.Deposit("USDC", 100)
+
+	var granted int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if id, err := ie.Reserve("USDC", 10); err == nil {
+				atomic.AddInt32(&granted, 1)
+				ie.Commit(id)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if granted != 10 {
+		t.Fatalf("%d reservations granted against a pool of 100 in units of 10, want 10", granted)
+	}
+	if got := ie.Pool("USDC"); got != 0 {
+		t.Fatalf("pool after commits = %g, want 0", got)
+	}
+}
+
+func TestHoldExpiryReturnsCapacity(t *testing.T) {
+	ie := NewIssuanceEngine()
+	ie.Deposit("USDC", 100)
+	ie.SetHoldTTL(time.Millisecond)
+
+	hold, err := ie.Reserve("USDC", 80)
+	if err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if got := ie.Available("USDC"); got != 100 {
+		t.Fatalf("available after hold expiry = %g, want 100", got)
+	}
+	if err := ie.Commit(hold); !errors.Is(err, ErrUnknownHold) {
+		t.Fatalf("committing an expired hold: %v, want ErrUnknownHold", err)
+	}
+}
+
+func TestIssuanceRequestSchema(t *testing.T) {
+	ie := NewIssuanceEngine()
+	ie.Deposit("USDC", 100)
+	ie.SetRequestSchema(&IssuanceSchema{
+		AllowedStablecoins: []string{"USDC"},
+		RecipientPattern:   regexp.MustCompile(`^acct-[0-9]+$`),
+		MinAmount:          1,
+		MaxAmount:          50,
+		MaxReferenceLength: 16,
+	})
+
+	if err := ie.IssueRequest(context.Background(), IssuanceRequest{
+		Stablecoin: "USDC", Recipient: "acct-42", Amount: 10,
+	}); err != nil {
+		t.Fatalf("valid request rejected: %v", err)
+	}
+
+	cases := []struct {
+		name  string
+		req   IssuanceRequest
+		field string
+	}{
+		{"disallowed type", IssuanceRequest{Stablecoin: "DOGE", Recipient: "acct-42", Amount: 10}, "stablecoin"},
+		{"bad recipient", IssuanceRequest{Stablecoin: "USDC", Recipient: "alice", Amount: 10}, "recipient"},
+		{"below min", IssuanceRequest{Stablecoin: "USDC", Recipient: "acct-42", Amount: 0.5}, "amount"},
+		{"above max", IssuanceRequest{Stablecoin: "USDC", Recipient: "acct-42", Amount: 500}, "amount"},
+		{"long reference", IssuanceRequest{Stablecoin: "USDC", Recipient: "acct-42", Amount: 10, Reference: strings.Repeat("x", 20)}, "reference"},
+	}
+	for _, tc := range cases {
+		err := ie.IssueRequest(context.Background(), tc.req)
+		if err == nil || !strings.Contains(err.Error(), "field "+tc.field) {
+			t.Errorf("%s: err = %v, want violation naming %s", tc.name, err, tc.field)
+		}
+	}
+
+	// Every violation is collected, not just the first.
+	err := ie.IssueRequest(context.Background(), IssuanceRequest{Stablecoin: "DOGE", Recipient: "alice", Amount: 500})
+	for _, field := range []string{"stablecoin", "recipient", "amount"} {
+		if err == nil || !strings.Contains(err.Error(), "field "+field) {
+			t.Fatalf("multi-violation error %v does not name %s", err, field)
+		}
+	}
+}
+
+func TestBreakerOracleTripsAndProbes(t *testing.T) {
+	inner := &oracleStub{err: errors.New("oracle timeout")}
+	bo := NewBreakerOracle(inner, 3, 20*time.Millisecond, DenyUnlessPass)
+
+	for i := 0; i < 3; i++ {
+		if err := bo.ApproveIssuance(context.Background(), "USDC", "alice", 1); err == nil {
+			t.Fatalf("failure %d not surfaced", i)
+		}
+	}
+	if bo.State() != BreakerOpen {
+		t.Fatalf("state after %d failures = %v, want open", 3, bo.State())
+	}
+
+	// While open, the oracle is not consulted and the policy decides.
+	before := atomic.LoadInt32(&inner.calls)
+	if err := bo.ApproveIssuance(context.Background(), "USDC", "alice", 1); !errors.Is(err, ErrOracleCircuitOpen) {
+		t.Fatalf("open breaker under DenyUnlessPass: %v, want ErrOracleCircuitOpen", err)
+	}
+	if atomic.LoadInt32(&inner.calls) != before {
+		t.Fatal("open breaker consulted the oracle")
+	}
+
+	// After the cooldown a probe goes through; success closes the breaker.
+	time.Sleep(25 * time.Millisecond)
+	inner.err = nil
+	if err := bo.ApproveIssuance(context.Background(), "USDC", "alice", 1); err != nil {
+		t.Fatalf("probe after cooldown: %v", err)
+	}
+	if bo.State() != BreakerClosed {
+		t.Fatalf("state after successful probe = %v, want closed", bo.State())
+	}
+}
+
+func TestBreakerOracleFailOpenPolicy(t *testing.T) {
+	inner := &oracleStub{err: errors.New("oracle timeout")}
+	bo := NewBreakerOracle(inner, 2, time.Minute, AllowUnlessFail)
+	for i := 0; i < 2; i++ {
+		bo.ApproveIssuance(context.Background(), "USDC", "alice", 1)
+	}
+	if err := bo.ApproveIssuance(context.Background(), "USDC", "alice", 1); err != nil {
+		t.Fatalf("open breaker under AllowUnlessFail: %v, want nil", err)
+	}
+}
+
+// attestStub returns a fresh attestation per call so cache hits are visible.
+type attestStub struct{ calls int32 }
+
+func (a *attestStub) Attest(ctx context.Context, stablecoin string) (ReserveAttestation, error) {
+	n := atomic.AddInt32(&a.calls, 1)
+	return ReserveAttestation{
+		Stablecoin: stablecoin,
+		Amount:     float64(1000 + n),
+		Timestamp:  time.Now(),
+		Signature:  "sig-" + stablecoin,
+	}, nil
+}
+
+func TestAttestationEndpointCachesUntilTTL(t *testing.T) {
+	provider := &attestStub{}
+	as := NewAttestationService(time.Hour)
+	as.Register("USDC", provider)
+
+	get := func() ReserveAttestation {
+		rec := httptest.NewRecorder()
+		as.Handler()(rec, httptest.NewRequest(http.MethodGet, "/reserve/attestation?stablecoin=USDC", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("attestation endpoint returned %d: %s", rec.Code, rec.Body)
+		}
+		var att ReserveAttestation
+		if err := json.Unmarshal(rec.Body.Bytes(), &att); err != nil {
+			t.Fatalf("decoding attestation: %v", err)
+		}
+		return att
+	}
+
+	first := get()
+	if first.Stablecoin != "USDC" || first.Signature != "sig-USDC" {
+		t.Fatalf("attestation = %+v", first)
+	}
+	if second := get(); second.Amount != first.Amount {
+		t.Fatal("cached attestation changed within the TTL")
+	}
+	if atomic.LoadInt32(&provider.calls) != 1 {
+		t.Fatalf("provider consulted %d times within TTL, want 1", provider.calls)
+	}
+
+	// Expire the cache and confirm a refresh.
+	as.mu.Lock()
+	c := as.cache["USDC"]
+	c.fetched = c.fetched.Add(-2 * time.Hour)
+	as.cache["USDC"] = c
+	as.mu.Unlock()
+	if refreshed := get(); refreshed.Amount == first.Amount {
+		t.Fatal("attestation not refreshed after TTL")
+	}
+
+	rec := httptest.NewRecorder()
+	as.Handler()(rec, httptest.NewRequest(http.MethodGet, "/reserve/attestation?stablecoin=UNKNOWN", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("unknown stablecoin returned %d, want 404", rec.Code)
+	}
+}
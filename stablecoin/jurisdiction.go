@@ -0,0 +1,36 @@
+package stablecoin
+
+import (
+	"strings"
+	"sync"
+)
+
+// Jurisdiction codes arrive inconsistently cased ("IMF", "imf", "Imf") and
+// under market aliases ("US" for the SEC regime). Lookups canonicalize
+// through this table so a valid jurisdiction is never silently rejected
+// over its spelling.
+var (
+	jurisdictionMu      sync.RWMutex
+	jurisdictionAliases = map[string]string{}
+)
+
+// RegisterJurisdictionAlias maps an incoming code to its canonical form,
+// e.g. RegisterJurisdictionAlias("US", "SEC"). Both sides are compared
+// case-insensitively.
+func RegisterJurisdictionAlias(alias, canonical string) {
+	jurisdictionMu.Lock()
+	jurisdictionAliases[strings.ToUpper(strings.TrimSpace(alias))] = strings.ToUpper(strings.TrimSpace(canonical))
+	jurisdictionMu.Unlock()
+}
+
+// CanonicalJurisdiction trims, uppercases, and resolves the configured
+// alias table.
+func CanonicalJurisdiction(code string) string {
+	canonical := strings.ToUpper(strings.TrimSpace(code))
+	jurisdictionMu.RLock()
+	if mapped, ok := jurisdictionAliases[canonical]; ok {
+		canonical = mapped
+	}
+	jurisdictionMu.RUnlock()
+	return canonical
+}
@@ -0,0 +1,52 @@
+package stablecoin
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// KYCLevel is the degree of identity verification a user has completed.
+type KYCLevel int
+
+const (
+	KYCNone KYCLevel = iota
+	KYCBasic
+	KYCFull
+)
+
+// KYCProvider verifies a user's identity under a specific jurisdiction's
+// rules.
+type KYCProvider interface {
+	Verify(ctx context.Context, userID, jurisdiction string) (KYCLevel, error)
+}
+
+// KYCRegistry maps jurisdiction codes to the provider responsible for them.
+type KYCRegistry struct {
+	mu        sync.RWMutex
+	providers map[string]KYCProvider
+}
+
+func NewKYCRegistry() *KYCRegistry {
+	return &KYCRegistry{providers: make(map[string]KYCProvider)}
+}
+
+func (r *KYCRegistry) Register(jurisdiction string, p KYCProvider) {
+	r.mu.Lock()
+	r.providers[CanonicalJurisdiction(jurisdiction)] = p
+	r.mu.Unlock()
+}
+
+// Lookup returns the provider registered for the jurisdiction, or an error
+// if none is registered. The code is canonicalized (case, whitespace,
+// aliases) before the lookup.
+func (r *KYCRegistry) Lookup(jurisdiction string) (KYCProvider, error) {
+	canonical := CanonicalJurisdiction(jurisdiction)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.providers[canonical]
+	if !ok {
+		return nil, fmt.Errorf("no KYC provider registered for jurisdiction %q", jurisdiction)
+	}
+	return p, nil
+}
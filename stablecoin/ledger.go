@@ -0,0 +1,238 @@
+package stablecoin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrEntryTooLarge is returned when an entry's data exceeds the ledger's
+// configured MaxEntryBytes.
+var ErrEntryTooLarge = errors.New("ledger entry data too large")
+
+// LedgerEntry is one immutable record in the ledger.
+type LedgerEntry struct {
+	ID        int64
+	Data      string
+	Amount    float64
+	Timestamp time.Time
+
+	// ReversalOf links a reversal entry to the entry it reverses; zero
+	// for ordinary entries.
+	ReversalOf int64
+	Reason     string
+
+	// Hash chains this entry to its predecessor:
+	// sha256(prevHash | data | amount | timestamp).
+	Hash string
+}
+
+// chainHash computes an entry's chain hash from its predecessor's.
+func chainHash(prevHash string, e LedgerEntry) string {
+	payload := prevHash + "|" + e.Data + "|" +
+		strconv.FormatFloat(e.Amount, 'g', -1, 64) + "|" +
+		strconv.FormatInt(e.Timestamp.UnixNano(), 10)
+	sum := sha256.Sum256([]byte(payload))
+	return hex.EncodeToString(sum[:])
+}
+
+// lastHash returns the chain hash of the newest entry. Callers hold l.mu.
+func (l *Ledger) lastHash() string {
+	if len(l.entries) == 0 {
+		return ""
+	}
+	return l.entries[len(l.entries)-1].Hash
+}
+
+type dedupRecord struct {
+	id    int64
+	added time.Time
+}
+
+// Ledger is an append-only, in-memory record store.
+type Ledger struct {
+	mu      sync.RWMutex
+	entries []LedgerEntry
+	nextID  int64
+
+	dedupWindow time.Duration // 0 disables duplicate detection
+	seen        map[string]dedupRecord
+
+	subs      map[int]*ledgerSub
+	nextSubID int
+
+	wal *ledgerWAL // nil for purely in-memory ledgers
+
+	maxEntryBytes int // 0 means unbounded
+
+	quorum int // minimum peer acks per write; 0 disables
+	peers  []ReplicationClient
+}
+
+func NewLedger() *Ledger {
+	return &Ledger{nextID: 1, seen: make(map[string]dedupRecord)}
+}
+
+// SetDedupWindow enables duplicate-entry detection: adding data whose
+// content hash was already stored within the window returns the existing
+// entry id instead of appending. A zero window disables dedup.
+func (l *Ledger) SetDedupWindow(window time.Duration) {
+	l.mu.Lock()
+	l.dedupWindow = window
+	l.mu.Unlock()
+}
+
+// SetMaxEntryBytes caps the size of an entry's data, keeping oversized
+// blobs out of memory and the WAL. A non-positive limit disables the cap.
+func (l *Ledger) SetMaxEntryBytes(n int) {
+	l.mu.Lock()
+	l.maxEntryBytes = n
+	l.mu.Unlock()
+}
+
+func contentKey(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+// AddEntry appends a record and returns its id. With dedup enabled, a
+// duplicate within the window returns the original entry's id.
+func (l *Ledger) AddEntry(data string) (int64, error) {
+	return l.AddAmountEntry(data, 0)
+}
+
+// AddAmountEntry appends a record carrying a monetary amount.
+func (l *Ledger) AddAmountEntry(data string, amount float64) (int64, error) {
+	if err := checkMaintenance(); err != nil {
+		return 0, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Size is checked before any hashing or storage work.
+	if l.maxEntryBytes > 0 && len(data) > l.maxEntryBytes {
+		return 0, fmt.Errorf("%w: %d bytes exceeds limit %d", ErrEntryTooLarge, len(data), l.maxEntryBytes)
+	}
+
+	now := time.Now()
+	key := contentKey(data)
+	if l.dedupWindow > 0 {
+		if rec, ok := l.seen[key]; ok && now.Sub(rec.added) < l.dedupWindow {
+			return rec.id, nil
+		}
+	}
+
+	id := l.nextID
+	l.nextID++
+	entry := LedgerEntry{
+		ID:        id,
+		Data:      data,
+		Amount:    amount,
+		Timestamp: now,
+	}
+	entry.Hash = chainHash(l.lastHash(), entry)
+	if err := l.replicateQuorum(entry); err != nil {
+		return 0, err
+	}
+	l.entries = append(l.entries, entry)
+	if l.wal != nil {
+		if err := l.wal.append(entry); err != nil {
+			l.entries = l.entries[:len(l.entries)-1]
+			l.nextID--
+			return 0, err
+		}
+	}
+	l.notifySubs(entry)
+	if l.dedupWindow > 0 {
+		l.seen[key] = dedupRecord{id: id, added: now}
+	}
+	return id, nil
+}
+
+// Reverse appends a reversal entry linked to the original, which is never
+// mutated. Reversing an entry that already has a reversal is rejected.
+func (l *Ledger) Reverse(id int64, reason string) (LedgerEntry, error) {
+	if err := checkMaintenance(); err != nil {
+		return LedgerEntry{}, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var original *LedgerEntry
+	for i := range l.entries {
+		e := &l.entries[i]
+		if e.ID == id {
+			original = e
+		}
+		if e.ReversalOf == id {
+			return LedgerEntry{}, fmt.Errorf("ledger entry %d is already reversed by entry %d", id, e.ID)
+		}
+	}
+	if original == nil {
+		return LedgerEntry{}, fmt.Errorf("ledger entry %d not found", id)
+	}
+	if original.ReversalOf != 0 {
+		return LedgerEntry{}, fmt.Errorf("ledger entry %d is itself a reversal", id)
+	}
+
+	reversal := LedgerEntry{
+		ID:         l.nextID,
+		Data:       original.Data,
+		Amount:     -original.Amount,
+		Timestamp:  time.Now(),
+		ReversalOf: id,
+		Reason:     reason,
+	}
+	reversal.Hash = chainHash(l.lastHash(), reversal)
+	if err := l.replicateQuorum(reversal); err != nil {
+		return LedgerEntry{}, err
+	}
+	l.nextID++
+	l.entries = append(l.entries, reversal)
+	if l.wal != nil {
+		if err := l.wal.append(reversal); err != nil {
+			l.entries = l.entries[:len(l.entries)-1]
+			l.nextID--
+			return LedgerEntry{}, err
+		}
+	}
+	l.notifySubs(reversal)
+	return reversal, nil
+}
+
+// NetBalance sums entry amounts; reversals carry the negated amount of
+// their original, so reversed entries net to zero.
+func (l *Ledger) NetBalance() float64 {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	var total float64
+	for _, e := range l.entries {
+		total += e.Amount
+	}
+	return total
+}
+
+// Entry returns the record with the given id.
+func (l *Ledger) Entry(id int64) (LedgerEntry, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for _, e := range l.entries {
+		if e.ID == id {
+			return e, nil
+		}
+	}
+	return LedgerEntry{}, fmt.Errorf("ledger entry %d not found", id)
+}
+
+// Entries returns a copy of all records in append order.
+func (l *Ledger) Entries() []LedgerEntry {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	out := make([]LedgerEntry, len(l.entries))
+	copy(out, l.entries)
+	return out
+}
@@ -0,0 +1,91 @@
+package stablecoin
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Format selects the ledger export encoding.
+type Format int
+
+const (
+	FormatCSV Format = iota
+	FormatJSONL
+)
+
+const exportChunkSize = 256
+
+// entriesChunk returns up to limit entries starting at the given index,
+// copied under the read lock so export never holds the lock while writing.
+func (l *Ledger) entriesChunk(start, limit int) []LedgerEntry {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if start >= len(l.entries) {
+		return nil
+	}
+	end := start + limit
+	if end > len(l.entries) {
+		end = len(l.entries)
+	}
+	out := make([]LedgerEntry, end-start)
+	copy(out, l.entries[start:end])
+	return out
+}
+
+// Export streams every ledger entry to w in the requested format, reading
+// the store in chunks so the full ledger is never held in memory. The
+// context is checked between chunks.
+func (l *Ledger) Export(ctx context.Context, w io.Writer, format Format) error {
+	var cw *csv.Writer
+	if format == FormatCSV {
+		cw = csv.NewWriter(w)
+		if err := cw.Write([]string{"id", "data", "timestamp"}); err != nil {
+			return err
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	for start := 0; ; start += exportChunkSize {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		chunk := l.entriesChunk(start, exportChunkSize)
+		if len(chunk) == 0 {
+			break
+		}
+		for _, e := range chunk {
+			switch format {
+			case FormatCSV:
+				record := []string{
+					strconv.FormatInt(e.ID, 10),
+					e.Data,
+					e.Timestamp.Format(time.RFC3339Nano),
+				}
+				if err := cw.Write(record); err != nil {
+					return err
+				}
+			case FormatJSONL:
+				if err := enc.Encode(e); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("unknown export format %d", format)
+			}
+		}
+		if format == FormatCSV {
+			cw.Flush()
+			if err := cw.Error(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
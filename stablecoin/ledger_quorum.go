@@ -0,0 +1,42 @@
+package stablecoin
+
+import "errors"
+
+// ErrNoQuorum is returned when a write cannot gather acknowledgments from
+// the configured quorum of peer replicas; the entry is not committed.
+var ErrNoQuorum = errors.New("insufficient replica acknowledgments")
+
+// ReplicationClient pushes a freshly appended entry to one peer replica. A
+// nil error is that peer's acknowledgment.
+type ReplicationClient interface {
+	Replicate(entry LedgerEntry) error
+}
+
+// SetReplication requires acknowledgment from at least quorum of the given
+// peers before a write commits. A zero quorum (or no peers) disables the
+// requirement. Quorums larger than the peer count can never be met and
+// reject every write.
+func (l *Ledger) SetReplication(quorum int, peers ...ReplicationClient) {
+	l.mu.Lock()
+	l.quorum = quorum
+	l.peers = peers
+	l.mu.Unlock()
+}
+
+// replicateQuorum offers the entry to every peer and reports whether enough
+// acknowledged. Callers hold l.mu and roll the entry back on failure.
+func (l *Ledger) replicateQuorum(e LedgerEntry) error {
+	if l.quorum <= 0 {
+		return nil
+	}
+	acks := 0
+	for _, peer := range l.peers {
+		if err := peer.Replicate(e); err == nil {
+			acks++
+		}
+	}
+	if acks < l.quorum {
+		return ErrNoQuorum
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package stablecoin
+
+import (
+	"fmt"
+	"sync"
+)
+
+// LedgerReplica is an in-memory, read-only copy of a primary Ledger, kept
+// eventually consistent through the subscribe API. Routing reads here keeps
+// high read load off the primary's write path: replica reads take only the
+// replica's lock, which writers on the primary never hold.
+type LedgerReplica struct {
+	mu      sync.RWMutex
+	entries []LedgerEntry
+
+	stop func()
+	done chan struct{}
+}
+
+// NewLedgerReplica attaches a replica to the primary and starts applying
+// its stream from the first entry. Call Close to detach.
+func NewLedgerReplica(primary *Ledger) *LedgerReplica {
+	ch, cancel := primary.Subscribe(0)
+	r := &LedgerReplica{stop: cancel, done: make(chan struct{})}
+	go r.apply(ch)
+	return r
+}
+
+func (r *LedgerReplica) apply(ch <-chan LedgerEntry) {
+	defer close(r.done)
+	for e := range ch {
+		r.mu.Lock()
+		r.entries = append(r.entries, e)
+		r.mu.Unlock()
+	}
+}
+
+// Close detaches the replica from the primary. Reads remain valid on the
+// last applied state.
+func (r *LedgerReplica) Close() {
+	r.stop()
+	<-r.done
+}
+
+// Len reports how many entries the replica has applied; comparing it to the
+// primary's entry count gives the replication lag.
+func (r *LedgerReplica) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.entries)
+}
+
+// Entry returns the record with the given id, if the replica has applied it.
+func (r *LedgerReplica) Entry(id int64) (LedgerEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, e := range r.entries {
+		if e.ID == id {
+			return e, nil
+		}
+	}
+	return LedgerEntry{}, fmt.Errorf("ledger entry %d not found on replica", id)
+}
+
+// Range returns a copy of the applied entries in [from, to) by append
+// offset, clamped to what the replica holds.
+func (r *LedgerReplica) Range(from, to int) []LedgerEntry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if from < 0 {
+		from = 0
+	}
+	if to > len(r.entries) {
+		to = len(r.entries)
+	}
+	if from >= to {
+		return nil
+	}
+	out := make([]LedgerEntry, to-from)
+	copy(out, r.entries[from:to])
+	return out
+}
+
+// NetBalance sums the applied entries' amounts.
+func (r *LedgerReplica) NetBalance() float64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var total float64
+	for _, e := range r.entries {
+		total += e.Amount
+	}
+	return total
+}
@@ -0,0 +1,102 @@
+package stablecoin
+
+import "sync"
+
+// ledgerSub buffers live appends for one subscriber until its goroutine can
+// deliver them.
+type ledgerSub struct {
+	mu     sync.Mutex
+	queue  []LedgerEntry
+	notify chan struct{}
+}
+
+func (s *ledgerSub) enqueue(e LedgerEntry) {
+	s.mu.Lock()
+	s.queue = append(s.queue, e)
+	s.mu.Unlock()
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (s *ledgerSub) drain() []LedgerEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := s.queue
+	s.queue = nil
+	return out
+}
+
+// Subscribe streams ledger entries starting at fromOffset (an index into
+// the append order): first the existing entries from that offset, then live
+// appends, with no gaps or duplicates at the boundary. The returned cancel
+// function stops the stream and closes the channel.
+func (l *Ledger) Subscribe(fromOffset int) (<-chan LedgerEntry, func()) {
+	l.mu.Lock()
+	if fromOffset < 0 {
+		fromOffset = 0
+	}
+	var historical []LedgerEntry
+	if fromOffset < len(l.entries) {
+		historical = make([]LedgerEntry, len(l.entries)-fromOffset)
+		copy(historical, l.entries[fromOffset:])
+	}
+	sub := &ledgerSub{notify: make(chan struct{}, 1)}
+	if l.subs == nil {
+		l.subs = make(map[int]*ledgerSub)
+	}
+	id := l.nextSubID
+	l.nextSubID++
+	l.subs[id] = sub
+	l.mu.Unlock()
+
+	out := make(chan LedgerEntry)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(out)
+		deliver := func(entries []LedgerEntry) bool {
+			for _, e := range entries {
+				select {
+				case out <- e:
+				case <-done:
+					return false
+				}
+			}
+			return true
+		}
+		if !deliver(historical) {
+			return
+		}
+		for {
+			select {
+			case <-sub.notify:
+				if !deliver(sub.drain()) {
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			l.mu.Lock()
+			delete(l.subs, id)
+			l.mu.Unlock()
+			close(done)
+		})
+	}
+	return out, cancel
+}
+
+// notifySubs fans a freshly appended entry out to every subscriber. Callers
+// must hold l.mu.
+func (l *Ledger) notifySubs(e LedgerEntry) {
+	for _, sub := range l.subs {
+		sub.enqueue(e)
+	}
+}
@@ -0,0 +1,270 @@
+package stablecoin
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLedgerDedupWindow(t *testing.T) {
+	l := NewLedger()
+	l.SetDedupWindow(time.Minute)
+
+	id1, err := l.AddEntry("transfer alice->bob 10")
+	if err != nil {
+		t.Fatalf("AddEntry: %v", err)
+	}
+	id2, err := l.AddEntry("transfer alice->bob 10")
+	if err != nil {
+		t.Fatalf("AddEntry: %v", err)
+	}
+	if id1 != id2 || len(l.Entries()) != 1 {
+		t.Fatalf("duplicate within window: ids %d/%d, %d entries; want one entry", id1, id2, len(l.Entries()))
+	}
+
+	off := NewLedger()
+	off.AddEntry("transfer alice->bob 10")
+	off.AddEntry("transfer alice->bob 10")
+	if got := len(off.Entries()); got != 2 {
+		t.Fatalf("dedup off: %d entries, want 2", got)
+	}
+}
+
+func TestLedgerReversal(t *testing.T) {
+	l := NewLedger()
+	id, err := l.AddAmountEntry("mint", 100)
+	if err != nil {
+		t.Fatalf("AddAmountEntry: %v", err)
+	}
+	reversal, err := l.Reverse(id, "operator error")
+	if err != nil {
+		t.Fatalf("Reverse: %v", err)
+	}
+	if reversal.ReversalOf != id || reversal.Amount != -100 {
+		t.Fatalf("reversal = %+v; want linked negated entry", reversal)
+	}
+	if net := l.NetBalance(); net != 0 {
+		t.Fatalf("net balance after reversal = %g, want 0", net)
+	}
+	if _, err := l.Reverse(id, "again"); err == nil {
+		t.Fatal("double reversal accepted")
+	}
+	if _, err := l.Reverse(reversal.ID, "reverse the reversal"); err == nil {
+		t.Fatal("reversing a reversal accepted")
+	}
+}
+
+func TestLedgerHashChain(t *testing.T) {
+	l := NewLedger()
+	l.AddEntry("one")
+	l.AddEntry("two")
+	l.AddEntry("three")
+
+	entries := l.Entries()
+	prev := ""
+	for i, e := range entries {
+		if e.Hash != chainHash(prev, e) {
+			t.Fatalf("entry %d hash does not chain from its predecessor", i)
+		}
+		prev = e.Hash
+	}
+}
+
+func TestLedgerMaxEntryBytes(t *testing.T) {
+	l := NewLedger()
+	l.SetMaxEntryBytes(8)
+	if _, err := l.AddEntry("12345678"); err != nil {
+		t.Fatalf("at-limit entry rejected: %v", err)
+	}
+	if _, err := l.AddEntry("123456789"); !errors.Is(err, ErrEntryTooLarge) {
+		t.Fatalf("over-limit entry: %v, want ErrEntryTooLarge", err)
+	}
+}
+
+func TestLedgerExportFormats(t *testing.T) {
+	l := NewLedger()
+	l.AddEntry("alpha")
+	l.AddEntry("beta")
+
+	var jsonl bytes.Buffer
+	if err := l.Export(context.Background(), &jsonl, FormatJSONL); err != nil {
+		t.Fatalf("Export JSONL: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(jsonl.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("JSONL export has %d lines, want 2", len(lines))
+	}
+	var e LedgerEntry
+	if err := json.Unmarshal([]byte(lines[0]), &e); err != nil || e.Data != "alpha" {
+		t.Fatalf("parsing JSONL line: %v, entry %+v", err, e)
+	}
+
+	var csvOut bytes.Buffer
+	if err := l.Export(context.Background(), &csvOut, FormatCSV); err != nil {
+		t.Fatalf("Export CSV: %v", err)
+	}
+	records, err := csv.NewReader(&csvOut).ReadAll()
+	if err != nil {
+		t.Fatalf("parsing CSV export: %v", err)
+	}
+	if len(records) != 3 || records[0][0] != "id" || records[1][1] != "alpha" || records[2][1] != "beta" {
+		t.Fatalf("CSV export = %v", records)
+	}
+}
+
+func TestLedgerImportCountsValidAndCorrupt(t *testing.T) {
+	src := NewLedger()
+	src.AddAmountEntry("seed 1", 10)
+	src.AddAmountEntry("seed 2", 20)
+	var export bytes.Buffer
+	if err := src.Export(context.Background(), &export, FormatJSONL); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	input := export.String() + "{not json}\n"
+
+	dst := NewLedger()
+	imported, skipped, err := dst.Import(strings.NewReader(input), false)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if imported != 2 || skipped != 1 {
+		t.Fatalf("imported=%d skipped=%d, want 2/1", imported, skipped)
+	}
+	if net := dst.NetBalance(); net != 30 {
+		t.Fatalf("net balance after import = %g, want 30", net)
+	}
+}
+
+func TestLedgerSubscribeMidStreamExactlyOnce(t *testing.T) {
+	l := NewLedger()
+	for i := 0; i < 5; i++ {
+		l.AddEntry(fmt.Sprintf("historical %d", i))
+	}
+
+	ch, cancel := l.Subscribe(2)
+	defer cancel()
+
+	var got []int64
+	for i := 0; i < 3; i++ {
+		e := <-ch
+		got = append(got, e.ID)
+	}
+	for i := 0; i < 2; i++ {
+		l.AddEntry(fmt.Sprintf("live %d", i))
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case e := <-ch:
+			got = append(got, e.ID)
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for live entry %d; got %v", i, got)
+		}
+	}
+
+	want := []int64{3, 4, 5, 6, 7}
+	if len(got) != len(want) {
+		t.Fatalf("received %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("received %v, want %v: gap or duplicate at the boundary", got, want)
+		}
+	}
+}
+
+func TestLedgerWALReplayAndCompact(t *testing.T) {
+	dir := t.TempDir()
+
+	l, err := OpenLedgerWAL(dir)
+	if err != nil {
+		t.Fatalf("OpenLedgerWAL: %v", err)
+	}
+	l.AddAmountEntry("one", 1)
+	l.AddAmountEntry("two", 2)
+
+	reopened, err := OpenLedgerWAL(dir)
+	if err != nil {
+		t.Fatalf("reopening: %v", err)
+	}
+	if got := len(reopened.Entries()); got != 2 {
+		t.Fatalf("replayed %d entries, want 2", got)
+	}
+	if net := reopened.NetBalance(); net != 3 {
+		t.Fatalf("replayed net balance = %g, want 3", net)
+	}
+
+	reopened.AddAmountEntry("three", 3)
+	if err := reopened.Compact(); err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	reopened.AddAmountEntry("four", 4)
+
+	final, err := OpenLedgerWAL(dir)
+	if err != nil {
+		t.Fatalf("reopening after compact: %v", err)
+	}
+	if got, net := len(final.Entries()), final.NetBalance(); got != 4 || net != 10 {
+		t.Fatalf("post-compaction replay: %d entries net %g, want 4 entries net 10", got, net)
+	}
+	if next, err := final.AddEntry("five"); err != nil || next != 5 {
+		t.Fatalf("id after replay = %d, %v; want 5", next, err)
+	}
+}
+
+func TestLedgerReplicaCatchesUp(t *testing.T) {
+	primary := NewLedger()
+	id, _ := primary.AddAmountEntry("pre-attach", 5)
+
+	replica := NewLedgerReplica(primary)
+	defer replica.Close()
+	primary.AddAmountEntry("post-attach", 7)
+
+	deadline := time.Now().Add(time.Second)
+	for replica.Len() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if replica.Len() != 2 {
+		t.Fatalf("replica applied %d entries, want 2", replica.Len())
+	}
+	if e, err := replica.Entry(id); err != nil || e.Data != "pre-attach" {
+		t.Fatalf("replica Entry(%d) = %+v, %v", id, e, err)
+	}
+	if net := replica.NetBalance(); net != 12 {
+		t.Fatalf("replica net balance = %g, want 12", net)
+	}
+}
+
+// replicaStub acknowledges or refuses every replication push.
+type replicaStub struct{ err error }
+
+func (r replicaStub) Replicate(entry LedgerEntry) error { return r.err }
+
+func TestLedgerReplicationQuorum(t *testing.T) {
+	l := NewLedger()
+	l.SetReplication(2,
+		replicaStub{},
+		replicaStub{},
+		replicaStub{err: errors.New("peer down")},
+	)
+	if _, err := l.AddEntry("meets quorum"); err != nil {
+		t.Fatalf("write with 2/3 acks rejected: %v", err)
+	}
+
+	l.SetReplication(2,
+		replicaStub{},
+		replicaStub{err: errors.New("peer down")},
+		replicaStub{err: errors.New("peer down")},
+	)
+	if _, err := l.AddEntry("fails quorum"); !errors.Is(err, ErrNoQuorum) {
+		t.Fatalf("write with 1/3 acks: %v, want ErrNoQuorum", err)
+	}
+	if got := len(l.Entries()); got != 1 {
+		t.Fatalf("failed write left %d entries, want 1", got)
+	}
+}
@@ -0,0 +1,131 @@
+package stablecoin
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	ledgerSnapshotFile = "snapshot.jsonl"
+	ledgerWALFile      = "wal.jsonl"
+)
+
+// ledgerWAL persists appends so the ledger can be replayed on startup.
+// Callers hold the ledger mutex around append and compact.
+type ledgerWAL struct {
+	dir  string
+	file *os.File
+}
+
+func (w *ledgerWAL) append(e LedgerEntry) error {
+	if err := json.NewEncoder(w.file).Encode(e); err != nil {
+		return err
+	}
+	return w.file.Sync()
+}
+
+// OpenLedgerWAL opens (or creates) a WAL-backed ledger in dir. Startup
+// replays the snapshot base file and then the WAL tail, reconstructing the
+// in-memory state; subsequent appends are written through to the WAL.
+func OpenLedgerWAL(dir string) (*Ledger, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	l := NewLedger()
+
+	for _, name := range []string{ledgerSnapshotFile, ledgerWALFile} {
+		entries, err := readEntriesFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		l.entries = append(l.entries, entries...)
+	}
+	for _, e := range l.entries {
+		if e.ID >= l.nextID {
+			l.nextID = e.ID + 1
+		}
+	}
+
+	f, err := os.OpenFile(filepath.Join(dir, ledgerWALFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	l.wal = &ledgerWAL{dir: dir, file: f}
+	return l, nil
+}
+
+func readEntriesFile(path string) ([]LedgerEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []LedgerEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var e LedgerEntry
+		if err := json.Unmarshal(line, &e); err != nil {
+			return nil, fmt.Errorf("replaying %s: %v", path, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
+
+// Compact writes a consistent snapshot of the current entries to a new base
+// file and truncates the WAL. The snapshot is written to a temp file,
+// fsynced, and atomically renamed before the WAL is touched, so a crash at
+// any point leaves a replayable state.
+func (l *Ledger) Compact() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.wal == nil {
+		return fmt.Errorf("ledger has no WAL to compact")
+	}
+
+	tmpPath := filepath.Join(l.wal.dir, ledgerSnapshotFile+".tmp")
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(tmp)
+	for _, e := range l.entries {
+		if err := enc.Encode(e); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, filepath.Join(l.wal.dir, ledgerSnapshotFile)); err != nil {
+		return err
+	}
+
+	// The snapshot now covers everything; truncate the WAL.
+	if err := l.wal.file.Close(); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(l.wal.dir, ledgerWALFile), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	l.wal.file = f
+	return nil
+}
@@ -0,0 +1,126 @@
+package stablecoin
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// TransactionTemplate is one request shape in a load profile: a prototype
+// transaction and the relative weight of traffic it should receive.
+type TransactionTemplate struct {
+	Name   string
+	Tx     Transaction
+	Weight float64
+}
+
+// LoadProfile describes a mixed workload: weighted transaction templates
+// fired at an aggregate arrival rate across a worker pool.
+type LoadProfile struct {
+	Templates []TransactionTemplate
+	Rate      float64 // transactions per second across all workers
+	Duration  time.Duration
+	Workers   int
+}
+
+// LoadStats aggregates one profile run. Counters are collected
+// concurrency-safely across workers.
+type LoadStats struct {
+	Total       int
+	Allowed     int
+	Rejected    int
+	Errors      int
+	PerTemplate map[string]int
+	Elapsed     time.Duration
+}
+
+// pickTemplate draws a template proportionally to the configured weights,
+// using the injected pseudo-random source so runs are reproducible.
+func (p LoadProfile) pickTemplate() TransactionTemplate {
+	var total float64
+	for _, t := range p.Templates {
+		total += t.Weight
+	}
+	draw := randFloat64() * total
+	for _, t := range p.Templates {
+		if draw < t.Weight {
+			return t
+		}
+		draw -= t.Weight
+	}
+	return p.Templates[len(p.Templates)-1]
+}
+
+// RunProfile drives the enforcer with the profile's template mix for its
+// duration, pacing submissions to the arrival rate and aggregating per-
+// template counts and outcome totals across workers.
+func (lt *LoadTester) RunProfile(ctx context.Context, target Enforcer, profile LoadProfile) (LoadStats, error) {
+	if len(profile.Templates) == 0 {
+		return LoadStats{}, fmt.Errorf("load profile has no templates")
+	}
+	workers := profile.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+
+	stats := LoadStats{PerTemplate: make(map[string]int)}
+	var statsMu sync.Mutex
+
+	interval := time.Duration(0)
+	if profile.Rate > 0 {
+		interval = time.Duration(float64(time.Second) / profile.Rate)
+	}
+
+	jobs := make(chan TransactionTemplate)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for tmpl := range jobs {
+				tx := tmpl.Tx
+				tx.ID = fmt.Sprintf("%s-load-%d-%d", tmpl.Name, worker, time.Now().UnixNano())
+				result, err := target.EnforceTransaction(ctx, tx)
+
+				statsMu.Lock()
+				stats.Total++
+				stats.PerTemplate[tmpl.Name]++
+				switch {
+				case err != nil:
+					stats.Errors++
+				case result.Allowed:
+					stats.Allowed++
+				default:
+					stats.Rejected++
+				}
+				statsMu.Unlock()
+			}
+		}(w)
+	}
+
+	start := time.Now()
+	deadline := start.Add(profile.Duration)
+feed:
+	for time.Now().Before(deadline) {
+		select {
+		case jobs <- profile.pickTemplate():
+		case <-ctx.Done():
+			break feed
+		}
+		if interval > 0 {
+			select {
+			case <-time.After(interval):
+			case <-ctx.Done():
+				break feed
+			}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	stats.Elapsed = time.Since(start)
+
+	lt.Record(fmt.Sprintf("profile run: total=%d allowed=%d rejected=%d errors=%d elapsed=%s",
+		stats.Total, stats.Allowed, stats.Rejected, stats.Errors, stats.Elapsed))
+	return stats, ctx.Err()
+}
@@ -0,0 +1,64 @@
+package stablecoin
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LoadResult is the report a profile run feeds into capacity planning:
+// aggregate stats plus latency percentiles, optionally signed so later
+// tampering is detectable.
+type LoadResult struct {
+	Stats LoadStats
+	P50   time.Duration
+	P95   time.Duration
+	P99   time.Duration
+
+	// Signature is the hex HMAC-SHA256 of the canonical report under the
+	// deployment key; empty for unsigned reports.
+	Signature string
+}
+
+// canonical renders every signed field deterministically (sorted template
+// keys), so signing and verification agree byte for byte.
+func (r LoadResult) canonical() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "total=%d|allowed=%d|rejected=%d|errors=%d|elapsed=%d|p50=%d|p95=%d|p99=%d",
+		r.Stats.Total, r.Stats.Allowed, r.Stats.Rejected, r.Stats.Errors,
+		r.Stats.Elapsed.Nanoseconds(), r.P50.Nanoseconds(), r.P95.Nanoseconds(), r.P99.Nanoseconds())
+	keys := make([]string, 0, len(r.Stats.PerTemplate))
+	for k := range r.Stats.PerTemplate {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&b, "|template.%s=%d", k, r.Stats.PerTemplate[k])
+	}
+	return b.String()
+}
+
+// SignLoadResult stamps the report with its signature under the deployment
+// key.
+func SignLoadResult(key []byte, r LoadResult) LoadResult {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(r.canonical()))
+	r.Signature = hex.EncodeToString(mac.Sum(nil))
+	return r
+}
+
+// VerifyLoadResult reports whether the report's contents still match its
+// signature: any modified counter or percentile fails verification.
+func VerifyLoadResult(key []byte, r LoadResult) bool {
+	if r.Signature == "" {
+		return false
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(r.canonical()))
+	expected := hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(r.Signature))
+}
@@ -0,0 +1,98 @@
+package stablecoin
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// defaultQuantumKey is derived from the strings historically baked into the
+// sealing code, so results recorded by earlier builds keep verifying.
+var defaultQuantumKey = []byte("pi-supernode-quantum-secure-v1")
+
+func secureWithKey(key []byte, result string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(result))
+	return result + "|" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func verifyWithKey(key []byte, secured string) bool {
+	i := strings.LastIndex(secured, "|")
+	if i < 0 {
+		return false
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(secured[:i]))
+	expected := hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(secured[i+1:]))
+}
+
+// quantumSecure seals a raw load-test result under the default key so
+// corruption of a recorded result can be detected later.
+func quantumSecure(result string) string {
+	return secureWithKey(defaultQuantumKey, result)
+}
+
+// VerifyResult recomputes the hash embedded in a secured result string and
+// reports whether the payload is intact.
+func VerifyResult(secured string) bool {
+	return verifyWithKey(defaultQuantumKey, secured)
+}
+
+type LoadTester struct {
+	mu      sync.Mutex
+	key     []byte
+	results []string
+}
+
+// LoadTesterOption configures a LoadTester at construction.
+type LoadTesterOption func(*LoadTester)
+
+// WithKey injects a deterministic sealing key so tests can assert exact
+// hash outputs. Test/dev-only: production deployments should rely on the
+// default key.
+func WithKey(key []byte) LoadTesterOption {
+	return func(lt *LoadTester) {
+		lt.key = key
+	}
+}
+
+func NewLoadTester(opts ...LoadTesterOption) *LoadTester {
+	lt := &LoadTester{key: defaultQuantumKey}
+	for _, opt := range opts {
+		opt(lt)
+	}
+	return lt
+}
+
+// Record seals and stores a single load-test result.
+func (lt *LoadTester) Record(result string) {
+	secured := secureWithKey(lt.key, result)
+	lt.mu.Lock()
+	lt.results = append(lt.results, secured)
+	lt.mu.Unlock()
+}
+
+// Results returns a copy of the recorded, secured results.
+func (lt *LoadTester) Results() []string {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	out := make([]string, len(lt.results))
+	copy(out, lt.results)
+	return out
+}
+
+// Verify runs the load tester in verify-only mode: every recorded result is
+// re-hashed and checked. It returns an error naming the first corrupted
+// entry, or nil if all results are intact.
+func (lt *LoadTester) Verify() error {
+	for i, secured := range lt.Results() {
+		if !verifyWithKey(lt.key, secured) {
+			return fmt.Errorf("load-test result %d failed hash verification", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package stablecoin
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerifyResultGenuine(t *testing.T) {
+	secured := quantumSecure("throughput=1200tps")
+	if !VerifyResult(secured) {
+		t.Fatalf("genuine secured result failed verification: %q", secured)
+	}
+}
+
+func TestVerifyResultTampered(t *testing.T) {
+	secured := quantumSecure("throughput=1200tps")
+	tampered := strings.Replace(secured, "1200", "9200", 1)
+	if tampered == secured {
+		t.Fatal("tampering did not change the payload")
+	}
+	if VerifyResult(tampered) {
+		t.Fatalf("tampered result passed verification: %q", tampered)
+	}
+	if VerifyResult("no separator here") {
+		t.Fatal("malformed result without separator passed verification")
+	}
+}
+
+func TestLoadTesterVerifyDetectsCorruption(t *testing.T) {
+	lt := NewLoadTester()
+	lt.Record("run A ok")
+	lt.Record("run B ok")
+	if err := lt.Verify(); err != nil {
+		t.Fatalf("Verify on intact results: %v", err)
+	}
+
+	lt.mu.Lock()
+	lt.results[1] = strings.Replace(lt.results[1], "ok", "KO", 1)
+	lt.mu.Unlock()
+	if err := lt.Verify(); err == nil {
+		t.Fatal("Verify accepted a corrupted recorded result")
+	}
+}
+
+func TestLoadTesterInjectedKeyProducesExpectedHash(t *testing.T) {
+	// Precomputed HMAC-SHA256 of "latency=42ms" under "test-key".
+	const want = "latency=42ms|8b579834026ec6a0548fb96d423671602b4d8d4116f33669f90ec3f8a631a305"
+	lt := NewLoadTester(WithKey([]byte("test-key")))
+	lt.Record("latency=42ms")
+	got := lt.Results()
+	if len(got) != 1 || got[0] != want {
+		t.Fatalf("secured result = %q, want %q", got, want)
+	}
+	if err := lt.Verify(); err != nil {
+		t.Fatalf("Verify under injected key: %v", err)
+	}
+}
+
+// stubEnforcer decides by a fixed function and counts calls; it stands in
+// for the regulatory enforcer in load and routing tests.
+type stubEnforcer struct {
+	decide func(tx Transaction) (EnforcementResult, error)
+}
+
+func (s *stubEnforcer) EnforceTransaction(ctx context.Context, tx Transaction) (EnforcementResult, error) {
+	if s.decide != nil {
+		return s.decide(tx)
+	}
+	return EnforcementResult{Allowed: true, Reason: "passed"}, nil
+}
+
+func TestRunProfileAggregatesPerTemplate(t *testing.T) {
+	target := &stubEnforcer{decide: func(tx Transaction) (EnforcementResult, error) {
+		if strings.HasPrefix(tx.ID, "hot-") {
+			return EnforcementResult{Allowed: false, Reason: "rejected"}, nil
+		}
+		return EnforcementResult{Allowed: true, Reason: "passed"}, nil
+	}}
+
+	lt := NewLoadTester()
+	profile := LoadProfile{
+		Templates: []TransactionTemplate{
+			{Name: "hot", Tx: Transaction{Origin: "load", Recipient: "sink", Amount: 1}, Weight: 1},
+			{Name: "cold", Tx: Transaction{Origin: "load", Recipient: "sink", Amount: 2}, Weight: 1},
+		},
+		Duration: 50 * time.Millisecond,
+		Workers:  4,
+	}
+	stats, err := lt.RunProfile(context.Background(), target, profile)
+	if err != nil {
+		t.Fatalf("RunProfile: %v", err)
+	}
+	if stats.Total == 0 {
+		t.Fatal("profile run submitted no transactions")
+	}
+	perTemplate := stats.PerTemplate["hot"] + stats.PerTemplate["cold"]
+	if perTemplate != stats.Total {
+		t.Fatalf("per-template counts sum to %d, total is %d", perTemplate, stats.Total)
+	}
+	if stats.Allowed+stats.Rejected+stats.Errors != stats.Total {
+		t.Fatalf("outcome counts %d+%d+%d do not sum to total %d",
+			stats.Allowed, stats.Rejected, stats.Errors, stats.Total)
+	}
+	if stats.Rejected != stats.PerTemplate["hot"] || stats.Allowed != stats.PerTemplate["cold"] {
+		t.Fatalf("outcomes do not line up with templates: %+v", stats)
+	}
+	if err := lt.Verify(); err != nil {
+		t.Fatalf("recorded profile summary failed verification: %v", err)
+	}
+}
+
+func TestRunProfileRequiresTemplates(t *testing.T) {
+	lt := NewLoadTester()
+	if _, err := lt.RunProfile(context.Background(), &stubEnforcer{}, LoadProfile{}); err == nil {
+		t.Fatal("RunProfile accepted an empty profile")
+	}
+}
+
+func TestSignedLoadResultRoundTrip(t *testing.T) {
+	key := []byte("capacity-planning-key")
+	result := LoadResult{
+		Stats: LoadStats{
+			Total: 100, Allowed: 90, Rejected: 8, Errors: 2,
+			PerTemplate: map[string]int{"hot": 40, "cold": 60},
+			Elapsed:     3 * time.Second,
+		},
+		P50: 5 * time.Millisecond,
+		P95: 20 * time.Millisecond,
+		P99: 45 * time.Millisecond,
+	}
+	signed := SignLoadResult(key, result)
+	if !VerifyLoadResult(key, signed) {
+		t.Fatal("genuine signed report failed verification")
+	}
+	if VerifyLoadResult(key, result) {
+		t.Fatal("unsigned report passed verification")
+	}
+}
+
+func TestSignedLoadResultDetectsModifiedPercentile(t *testing.T) {
+	key := []byte("capacity-planning-key")
+	signed := SignLoadResult(key, LoadResult{
+		Stats: LoadStats{Total: 10, Allowed: 10},
+		P50:   5 * time.Millisecond,
+		P95:   20 * time.Millisecond,
+		P99:   45 * time.Millisecond,
+	})
+	signed.P95 = 6 * time.Millisecond
+	if VerifyLoadResult(key, signed) {
+		t.Fatal("report with modified percentile passed verification")
+	}
+}
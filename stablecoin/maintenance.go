@@ -0,0 +1,63 @@
+package stablecoin
+
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+)
+
+// ErrMaintenance is returned by mutating operations while maintenance mode
+// is enabled. Read paths are unaffected.
+var ErrMaintenance = errors.New("service is in maintenance mode")
+
+var maintenanceMode uint32
+
+// SetMaintenanceMode toggles the global maintenance flag. While enabled,
+// IssueStablecoin, ConvertPiCoin and ledger AddEntry reject with
+// ErrMaintenance.
+func SetMaintenanceMode(on bool) {
+	var v uint32
+	if on {
+		v = 1
+	}
+	atomic.StoreUint32(&maintenanceMode, v)
+}
+
+// MaintenanceModeEnabled reports whether maintenance mode is on.
+func MaintenanceModeEnabled() bool {
+	return atomic.LoadUint32(&maintenanceMode) == 1
+}
+
+// checkMaintenance is called at the top of every mutating operation.
+func checkMaintenance() error {
+	if MaintenanceModeEnabled() {
+		return ErrMaintenance
+	}
+	return nil
+}
+
+// MaintenanceHandler is the admin API for the flag:
+//
+//	POST /admin/maintenance?enabled=true|false
+//	GET  /admin/maintenance
+func MaintenanceHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			switch r.URL.Query().Get("enabled") {
+			case "true":
+				SetMaintenanceMode(true)
+			case "false":
+				SetMaintenanceMode(false)
+			default:
+				http.Error(w, "enabled must be true or false", http.StatusBadRequest)
+				return
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if MaintenanceModeEnabled() {
+			w.Write([]byte(`{"maintenance":true}`))
+		} else {
+			w.Write([]byte(`{"maintenance":false}`))
+		}
+	}
+}
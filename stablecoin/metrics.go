@@ -0,0 +1,136 @@
+package stablecoin
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Counter is a monotonically increasing metric.
+type Counter struct {
+	v uint64
+}
+
+func (c *Counter) Inc()          { atomic.AddUint64(&c.v, 1) }
+func (c *Counter) Add(n uint64)  { atomic.AddUint64(&c.v, n) }
+func (c *Counter) Value() uint64 { return atomic.LoadUint64(&c.v) }
+
+// Histogram records observations and tracks count and sum.
+type Histogram struct {
+	mu    sync.Mutex
+	count uint64
+	sum   float64
+}
+
+func (h *Histogram) Observe(v float64) {
+	h.mu.Lock()
+	h.count++
+	h.sum += v
+	h.mu.Unlock()
+}
+
+func (h *Histogram) Count() uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.count
+}
+
+func (h *Histogram) Sum() float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.sum
+}
+
+// Metrics is a process-local registry of named, labeled metrics.
+type Metrics struct {
+	mu         sync.Mutex
+	counters   map[string]*Counter
+	histograms map[string]*Histogram
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{
+		counters:   make(map[string]*Counter),
+		histograms: make(map[string]*Histogram),
+	}
+}
+
+// DefaultMetrics is the registry used by components that are not wired to a
+// dedicated one.
+var DefaultMetrics = NewMetrics()
+
+func metricKey(name string, labels map[string]string) string {
+	if len(labels) == 0 {
+		return name
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	key := name + "{"
+	for i, k := range keys {
+		if i > 0 {
+			key += ","
+		}
+		key += fmt.Sprintf("%s=%q", k, labels[k])
+	}
+	return key + "}"
+}
+
+// Counter returns (creating if needed) the counter for name and labels.
+func (m *Metrics) Counter(name string, labels map[string]string) *Counter {
+	key := metricKey(name, labels)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.counters[key]
+	if !ok {
+		c = &Counter{}
+		m.counters[key] = c
+	}
+	return c
+}
+
+// HistogramSnapshot is the aggregate state of one histogram.
+type HistogramSnapshot struct {
+	Count uint64
+	Sum   float64
+}
+
+// MetricsSnapshot is a point-in-time copy of every registered metric, keyed
+// by the full name{labels} string.
+type MetricsSnapshot struct {
+	Counters   map[string]uint64
+	Histograms map[string]HistogramSnapshot
+}
+
+// Snapshot copies the current value of every metric for export.
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	snap := MetricsSnapshot{
+		Counters:   make(map[string]uint64, len(m.counters)),
+		Histograms: make(map[string]HistogramSnapshot, len(m.histograms)),
+	}
+	for key, c := range m.counters {
+		snap.Counters[key] = c.Value()
+	}
+	for key, h := range m.histograms {
+		snap.Histograms[key] = HistogramSnapshot{Count: h.Count(), Sum: h.Sum()}
+	}
+	return snap
+}
+
+// Histogram returns (creating if needed) the histogram for name and labels.
+func (m *Metrics) Histogram(name string, labels map[string]string) *Histogram {
+	key := metricKey(name, labels)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	h, ok := m.histograms[key]
+	if !ok {
+		h = &Histogram{}
+		m.histograms[key] = h
+	}
+	return h
+}
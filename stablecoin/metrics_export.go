@@ -0,0 +1,147 @@
+package stablecoin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MetricsExporter pushes a metrics snapshot to an external collector.
+type MetricsExporter interface {
+	Export(snap MetricsSnapshot) error
+}
+
+// StatsDExporter emits one StatsD line per metric over UDP. Label sets are
+// folded into the metric name since StatsD has no native labels.
+type StatsDExporter struct {
+	conn net.Conn
+}
+
+func NewStatsDExporter(addr string) (*StatsDExporter, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return &StatsDExporter{conn: conn}, nil
+}
+
+func statsdName(key string) string {
+	r := strings.NewReplacer("{", ".", "}", "", "=", "_", ",", ".", `"`, "", " ", "_")
+	return r.Replace(key)
+}
+
+func (e *StatsDExporter) Export(snap MetricsSnapshot) error {
+	var buf bytes.Buffer
+	for key, v := range snap.Counters {
+		fmt.Fprintf(&buf, "%s:%d|c\n", statsdName(key), v)
+	}
+	for key, h := range snap.Histograms {
+		fmt.Fprintf(&buf, "%s.count:%d|c\n", statsdName(key), h.Count)
+		fmt.Fprintf(&buf, "%s.sum:%g|g\n", statsdName(key), h.Sum)
+	}
+	_, err := e.conn.Write(buf.Bytes())
+	return err
+}
+
+func (e *StatsDExporter) Close() error { return e.conn.Close() }
+
+// OTLPExporter pushes the snapshot as JSON to an OTLP/HTTP collector
+// endpoint.
+type OTLPExporter struct {
+	endpoint string
+	client   *http.Client
+}
+
+func NewOTLPExporter(endpoint string) *OTLPExporter {
+	return &OTLPExporter{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (e *OTLPExporter) Export(snap MetricsSnapshot) error {
+	body, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	resp, err := e.client.Post(e.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("OTLP collector returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// NewMetricsExporter builds an exporter from a config spec: "statsd:<addr>"
+// or "otlp:<url>".
+func NewMetricsExporter(spec string) (MetricsExporter, error) {
+	switch {
+	case strings.HasPrefix(spec, "statsd:"):
+		return NewStatsDExporter(strings.TrimPrefix(spec, "statsd:"))
+	case strings.HasPrefix(spec, "otlp:"):
+		return NewOTLPExporter(strings.TrimPrefix(spec, "otlp:")), nil
+	default:
+		return nil, fmt.Errorf("unknown metrics exporter %q", spec)
+	}
+}
+
+// MetricsPusher flushes a Metrics registry to an exporter on an interval.
+type MetricsPusher struct {
+	metrics  *Metrics
+	exporter MetricsExporter
+	interval time.Duration
+
+	closeOnce sync.Once
+	done      chan struct{}
+	wg        sync.WaitGroup
+}
+
+func NewMetricsPusher(metrics *Metrics, exporter MetricsExporter, interval time.Duration) *MetricsPusher {
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	return &MetricsPusher{
+		metrics:  metrics,
+		exporter: exporter,
+		interval: interval,
+		done:     make(chan struct{}),
+	}
+}
+
+// Start begins periodic pushes until Stop is called.
+func (p *MetricsPusher) Start() {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.exporter.Export(p.metrics.Snapshot())
+			case <-p.done:
+				return
+			}
+		}
+	}()
+}
+
+// Flush pushes the current snapshot immediately.
+func (p *MetricsPusher) Flush() error {
+	return p.exporter.Export(p.metrics.Snapshot())
+}
+
+// Stop halts periodic pushes after a final flush.
+func (p *MetricsPusher) Stop() {
+	p.closeOnce.Do(func() { close(p.done) })
+	p.wg.Wait()
+	p.Flush()
+}
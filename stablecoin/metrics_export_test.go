@@ -0,0 +1,72 @@
+package stablecoin
+
+import (
+	"net"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatsDExporterFlush(t *testing.T) {
+	receiver, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("starting fake StatsD receiver: %v", err)
+	}
+	defer receiver.Close()
+
+	metrics := NewMetrics()
+	metrics.Counter("decisions_total", map[string]string{"origin": "alice"}).Add(3)
+	metrics.Counter("drops_total", nil).Inc()
+	h := metrics.Histogram("latency_seconds", nil)
+	h.Observe(0.5)
+	h.Observe(1.0)
+
+	exporter, err := NewStatsDExporter(receiver.LocalAddr().String())
+	if err != nil {
+		t.Fatalf("NewStatsDExporter: %v", err)
+	}
+	defer exporter.Close()
+
+	pusher := NewMetricsPusher(metrics, exporter, time.Hour)
+	if err := pusher.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	receiver.SetReadDeadline(time.Now().Add(2 * time.Second))
+	buf := make([]byte, 64*1024)
+	n, _, err := receiver.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("reading flushed datagram: %v", err)
+	}
+	got := strings.Split(strings.TrimSpace(string(buf[:n])), "\n")
+	sort.Strings(got)
+
+	want := []string{
+		"decisions_total.origin_alice:3|c",
+		"drops_total:1|c",
+		"latency_seconds.count:2|c",
+		"latency_seconds.sum:1.5|g",
+	}
+	sort.Strings(want)
+	if len(got) != len(want) {
+		t.Fatalf("flushed lines = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("flushed lines = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestNewMetricsExporterSpecs(t *testing.T) {
+	if _, err := NewMetricsExporter("statsd:127.0.0.1:8125"); err != nil {
+		t.Fatalf("statsd spec: %v", err)
+	}
+	if _, err := NewMetricsExporter("otlp:http://collector:4318/v1/metrics"); err != nil {
+		t.Fatalf("otlp spec: %v", err)
+	}
+	if _, err := NewMetricsExporter("graphite:whatever"); err == nil {
+		t.Fatal("unknown exporter spec accepted")
+	}
+}
@@ -0,0 +1,63 @@
+package stablecoin
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInputTooLarge is returned when an inference input exceeds the
+// configured size limit, before any tensor is built.
+var ErrInputTooLarge = errors.New("inference input exceeds maximum length")
+
+// DefaultMaxInputLength bounds the number of feature elements accepted by
+// instrumented models. Oversized inputs are rejected before reaching the
+// model so a hostile transaction cannot exhaust memory via tf.NewTensor.
+const DefaultMaxInputLength = 4096
+
+// Model scores a transaction feature vector. Implementations wrap loaded
+// TensorFlow graphs, remote scorers or test stubs.
+type Model interface {
+	Name() string
+	Predict(features []float64) (float64, error)
+}
+
+// InstrumentedModel wraps a Model and records per-model inference latency
+// and failures:
+//
+//	model_inference_seconds{model}
+//	model_inference_errors_total{model}
+type InstrumentedModel struct {
+	inner    Model
+	metrics  *Metrics
+	maxInput int
+}
+
+func InstrumentModel(m Model, metrics *Metrics) *InstrumentedModel {
+	if metrics == nil {
+		metrics = DefaultMetrics
+	}
+	return &InstrumentedModel{inner: m, metrics: metrics, maxInput: DefaultMaxInputLength}
+}
+
+// SetMaxInputLength overrides the input size limit. Non-positive disables
+// the check.
+func (im *InstrumentedModel) SetMaxInputLength(n int) {
+	im.maxInput = n
+}
+
+func (im *InstrumentedModel) Name() string { return im.inner.Name() }
+
+func (im *InstrumentedModel) Predict(features []float64) (float64, error) {
+	labels := map[string]string{"model": im.inner.Name()}
+	if im.maxInput > 0 && len(features) > im.maxInput {
+		im.metrics.Counter("model_input_too_large_total", labels).Inc()
+		return 0, ErrInputTooLarge
+	}
+	start := time.Now()
+	score, err := im.inner.Predict(features)
+	im.metrics.Histogram("model_inference_seconds", labels).Observe(time.Since(start).Seconds())
+	if err != nil {
+		im.metrics.Counter("model_inference_errors_total", labels).Inc()
+	}
+	return score, err
+}
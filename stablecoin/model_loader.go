@@ -0,0 +1,26 @@
+package stablecoin
+
+import "fmt"
+
+// ModelLoader loads a model from a filesystem path.
+type ModelLoader func(path string) (Model, error)
+
+// LoadModelWithFallback loads the primary model, falling back to the
+// secondary path when the primary is unavailable. It returns the model and
+// the path that was actually used. Only if both paths fail does the caller
+// drop to keyword heuristics.
+func LoadModelWithFallback(primary, secondary string, load ModelLoader) (Model, string, error) {
+	m, primaryErr := load(primary)
+	if primaryErr == nil {
+		return m, primary, nil
+	}
+	if secondary == "" {
+		return nil, "", fmt.Errorf("loading model %s: %v", primary, primaryErr)
+	}
+	m, secondaryErr := load(secondary)
+	if secondaryErr == nil {
+		return m, secondary, nil
+	}
+	return nil, "", fmt.Errorf("loading model %s: %v; fallback %s: %v",
+		primary, primaryErr, secondary, secondaryErr)
+}
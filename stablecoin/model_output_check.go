@@ -0,0 +1,27 @@
+package stablecoin
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidModelOutput is returned when a model emits NaN or Inf, which no
+// threshold comparison can interpret meaningfully.
+var ErrInvalidModelOutput = errors.New("model produced non-finite output")
+
+// predictChecked runs the model and rejects non-finite outputs as inference
+// errors, so numerical instability follows the same fallback policy as a
+// failed inference instead of leaking into threshold comparisons. Invalid
+// outputs increment model_output_invalid_total{model}.
+func predictChecked(m Model, features []float64) (float64, error) {
+	score, err := m.Predict(features)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(score) || math.IsInf(score, 0) {
+		DefaultMetrics.Counter("model_output_invalid_total", map[string]string{"model": m.Name()}).Inc()
+		return 0, fmt.Errorf("%w: %g", ErrInvalidModelOutput, score)
+	}
+	return score, nil
+}
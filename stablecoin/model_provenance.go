@@ -0,0 +1,97 @@
+package stablecoin
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// ModelDigest computes a deterministic SHA-256 digest over every file in a
+// saved-model directory (relative path and content), so a signature can
+// cover the whole artifact.
+func ModelDigest(dir string) (string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+
+	h := sha256.New()
+	for _, path := range files {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return "", err
+		}
+		io.WriteString(h, rel)
+		h.Write([]byte{0})
+		f, err := os.Open(path)
+		if err != nil {
+			return "", err
+		}
+		if _, err := io.Copy(h, f); err != nil {
+			f.Close()
+			return "", err
+		}
+		f.Close()
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// SignedModelLoader refuses to load a saved model unless a detached ed25519
+// signature over the model digest verifies against the configured public
+// key.
+type SignedModelLoader struct {
+	pub ed25519.PublicKey
+
+	mu             sync.Mutex
+	verifiedDigest string
+}
+
+func NewSignedModelLoader(pub ed25519.PublicKey) *SignedModelLoader {
+	return &SignedModelLoader{pub: pub}
+}
+
+// LoadSavedModel verifies the detached signature file for dir and, on
+// success, records the digest and hands the directory to load. A bad or
+// missing signature never reaches the model loader.
+func (sl *SignedModelLoader) LoadSavedModel(dir, sigPath string, load func(dir string) (Model, error)) (Model, error) {
+	digest, err := ModelDigest(dir)
+	if err != nil {
+		return nil, fmt.Errorf("digesting model %s: %v", dir, err)
+	}
+	sig, err := os.ReadFile(sigPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading model signature: %v", err)
+	}
+	if !ed25519.Verify(sl.pub, []byte(digest), sig) {
+		return nil, fmt.Errorf("model %s failed provenance verification", dir)
+	}
+
+	sl.mu.Lock()
+	sl.verifiedDigest = digest
+	sl.mu.Unlock()
+	return load(dir)
+}
+
+// VerifiedDigest returns the digest of the most recently verified model.
+func (sl *SignedModelLoader) VerifiedDigest() string {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	return sl.verifiedDigest
+}
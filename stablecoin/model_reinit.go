@@ -0,0 +1,114 @@
+package stablecoin
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ReinitializingModel wraps a loaded model and recovers from a dead
+// inference session without a process restart: when inference fails
+// consistently with a session-level error, the model is reloaded from its
+// cached path under exponential backoff. Between reinit attempts the
+// wrapper keeps failing fast, which is the behavior upstream fallback and
+// circuit-breaking layers expect from an unhealthy model.
+type ReinitializingModel struct {
+	path string
+	load ModelLoader
+
+	// isSessionError classifies which failures indicate a dead session
+	// (as opposed to bad input). By default every error counts.
+	isSessionError func(error) bool
+
+	failureThreshold int
+	baseBackoff      time.Duration
+	maxBackoff       time.Duration
+
+	mu           sync.Mutex
+	model        Model
+	consecutive  int
+	backoff      time.Duration
+	nextReinitAt time.Time
+}
+
+func NewReinitializingModel(path string, load ModelLoader) (*ReinitializingModel, error) {
+	m, err := load(path)
+	if err != nil {
+		return nil, err
+	}
+	return &ReinitializingModel{
+		path:             path,
+		load:             load,
+		model:            m,
+		failureThreshold: 3,
+		baseBackoff:      time.Second,
+		maxBackoff:       time.Minute,
+	}, nil
+}
+
+// SetSessionErrorMatcher restricts which inference errors count toward
+// session death; others (for example input validation) do not trigger
+// reinitialization.
+func (rm *ReinitializingModel) SetSessionErrorMatcher(match func(error) bool) {
+	rm.mu.Lock()
+	rm.isSessionError = match
+	rm.mu.Unlock()
+}
+
+// SetReinitPolicy tunes how many consecutive session errors trigger a
+// reload and the backoff bounds between reload attempts.
+func (rm *ReinitializingModel) SetReinitPolicy(threshold int, base, max time.Duration) {
+	rm.mu.Lock()
+	rm.failureThreshold = threshold
+	rm.baseBackoff = base
+	rm.maxBackoff = max
+	rm.mu.Unlock()
+}
+
+func (rm *ReinitializingModel) Name() string {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	return rm.model.Name()
+}
+
+func (rm *ReinitializingModel) Predict(features []float64) (float64, error) {
+	rm.mu.Lock()
+	model := rm.model
+	rm.mu.Unlock()
+
+	score, err := model.Predict(features)
+	if err == nil {
+		rm.mu.Lock()
+		rm.consecutive = 0
+		rm.backoff = 0
+		rm.mu.Unlock()
+		return score, nil
+	}
+
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	if rm.isSessionError != nil && !rm.isSessionError(err) {
+		return 0, err
+	}
+	rm.consecutive++
+	if rm.consecutive < rm.failureThreshold || time.Now().Before(rm.nextReinitAt) {
+		return 0, err
+	}
+
+	// The session looks dead and the backoff window has passed: reload
+	// from the cached path. A failed reload widens the window.
+	if rm.backoff == 0 {
+		rm.backoff = rm.baseBackoff
+	} else if rm.backoff *= 2; rm.backoff > rm.maxBackoff {
+		rm.backoff = rm.maxBackoff
+	}
+	rm.nextReinitAt = time.Now().Add(rm.backoff)
+
+	fresh, loadErr := rm.load(rm.path)
+	if loadErr != nil {
+		return 0, fmt.Errorf("inference failed (%v) and session reinit failed: %v", err, loadErr)
+	}
+	rm.model = fresh
+	rm.consecutive = 0
+	return 0, fmt.Errorf("inference failed, session reinitialized: %w", err)
+}
@@ -0,0 +1,78 @@
+package stablecoin
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// modelHandle wraps one loaded model generation with a reference count, so
+// a swapped-out model is only closed once its in-flight inferences finish.
+type modelHandle struct {
+	model   Model
+	refs    int64
+	retired uint32
+}
+
+func (h *modelHandle) release() {
+	if atomic.AddInt64(&h.refs, -1) == 0 && atomic.LoadUint32(&h.retired) == 1 {
+		h.close()
+	}
+}
+
+func (h *modelHandle) close() {
+	if c, ok := h.model.(io.Closer); ok {
+		c.Close()
+	}
+}
+
+// SwappableModel is a Model whose backing model can be replaced at runtime
+// without cutting off in-flight inferences. Predict calls that started on
+// the old model finish on it; the old model is closed (if it implements
+// io.Closer) only after the last such call returns. New calls immediately
+// use the new model.
+type SwappableModel struct {
+	mu      sync.Mutex
+	current *modelHandle
+}
+
+func NewSwappableModel(model Model) *SwappableModel {
+	return &SwappableModel{current: &modelHandle{model: model, refs: 1}}
+}
+
+// acquire pins the current generation for one inference.
+func (sm *SwappableModel) acquire() *modelHandle {
+	sm.mu.Lock()
+	h := sm.current
+	atomic.AddInt64(&h.refs, 1)
+	sm.mu.Unlock()
+	return h
+}
+
+// Reload swaps in the replacement model. The previous model keeps serving
+// its outstanding inferences and is closed when they drain.
+func (sm *SwappableModel) Reload(model Model) {
+	next := &modelHandle{model: model, refs: 1}
+	sm.mu.Lock()
+	old := sm.current
+	sm.current = next
+	sm.mu.Unlock()
+
+	atomic.StoreUint32(&old.retired, 1)
+	old.release() // drop the generation's own reference
+}
+
+// Name reports the name of the currently active model.
+func (sm *SwappableModel) Name() string {
+	h := sm.acquire()
+	defer h.release()
+	return h.model.Name()
+}
+
+// Predict runs the inference on whichever model was active when the call
+// started.
+func (sm *SwappableModel) Predict(features []float64) (float64, error) {
+	h := sm.acquire()
+	defer h.release()
+	return h.model.Predict(features)
+}
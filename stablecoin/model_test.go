@@ -0,0 +1,262 @@
+package stablecoin
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestInstrumentedModelMetrics(t *testing.T) {
+	metrics := NewMetrics()
+	labels := map[string]string{"model": "m"}
+
+	ok := InstrumentModel(&modelStub{name: "m", score: 0.2}, metrics)
+	ok.Predict([]float64{1})
+	ok.Predict([]float64{2})
+	if got := metrics.Histogram("model_inference_seconds", labels).Count(); got != 2 {
+		t.Fatalf("latency histogram recorded %d observations, want 2", got)
+	}
+	if got := metrics.Counter("model_inference_errors_total", labels).Value(); got != 0 {
+		t.Fatalf("error counter = %d after clean inferences, want 0", got)
+	}
+
+	failing := InstrumentModel(&modelStub{name: "m", err: errors.New("boom")}, metrics)
+	failing.Predict([]float64{1})
+	if got := metrics.Counter("model_inference_errors_total", labels).Value(); got != 1 {
+		t.Fatalf("error counter = %d after a failing inference, want 1", got)
+	}
+	// The failed inference still records its latency.
+	if got := metrics.Histogram("model_inference_seconds", labels).Count(); got != 3 {
+		t.Fatalf("latency histogram recorded %d observations, want 3", got)
+	}
+}
+
+func TestSignedModelLoaderProvenance(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "weights.pb"), []byte("weights"), 0o600); err != nil {
+		t.Fatalf("writing model file: %v", err)
+	}
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	digest, err := ModelDigest(dir)
+	if err != nil {
+		t.Fatalf("ModelDigest: %v", err)
+	}
+	sigPath := filepath.Join(t.TempDir(), "model.sig")
+	if err := os.WriteFile(sigPath, ed25519.Sign(priv, []byte(digest)), 0o600); err != nil {
+		t.Fatalf("writing signature: %v", err)
+	}
+
+	sl := NewSignedModelLoader(pub)
+	var loaded int32
+	load := func(dir string) (Model, error) {
+		atomic.AddInt32(&loaded, 1)
+		return &modelStub{name: "signed"}, nil
+	}
+
+	m, err := sl.LoadSavedModel(dir, sigPath, load)
+	if err != nil || m.Name() != "signed" {
+		t.Fatalf("LoadSavedModel with a valid signature: %v, %v", m, err)
+	}
+	if sl.VerifiedDigest() != digest {
+		t.Fatalf("VerifiedDigest = %q, want %q", sl.VerifiedDigest(), digest)
+	}
+
+	// Tampering with the artifact after signing must keep the loader shut.
+	if err := os.WriteFile(filepath.Join(dir, "weights.pb"), []byte("patched"), 0o600); err != nil {
+		t.Fatalf("tampering with model file: %v", err)
+	}
+	if _, err := sl.LoadSavedModel(dir, sigPath, load); err == nil {
+		t.Fatal("tampered model passed provenance verification")
+	}
+	if got := atomic.LoadInt32(&loaded); got != 1 {
+		t.Fatalf("model loader ran %d times, want 1: a bad signature must not reach it", got)
+	}
+}
+
+func TestLoadModelWithFallback(t *testing.T) {
+	load := func(path string) (Model, error) {
+		if path == "models/bad.pb" {
+			return nil, errors.New("corrupt")
+		}
+		return &modelStub{name: path}, nil
+	}
+
+	m, used, err := LoadModelWithFallback("models/good.pb", "models/backup.pb", load)
+	if err != nil || used != "models/good.pb" || m.Name() != "models/good.pb" {
+		t.Fatalf("healthy primary: %v, %q, %v", m, used, err)
+	}
+
+	m, used, err = LoadModelWithFallback("models/bad.pb", "models/backup.pb", load)
+	if err != nil || used != "models/backup.pb" || m.Name() != "models/backup.pb" {
+		t.Fatalf("primary down: %v, %q, %v; want the secondary", m, used, err)
+	}
+
+	if _, _, err := LoadModelWithFallback("models/bad.pb", "models/bad.pb", load); err == nil {
+		t.Fatal("both paths failing did not surface an error")
+	}
+}
+
+// closableModel fails any Predict that arrives after Close, which is
+// exactly the use-after-close a hot swap must never produce.
+type closableModel struct {
+	modelStub
+	closed uint32
+}
+
+func (m *closableModel) Close() error {
+	atomic.StoreUint32(&m.closed, 1)
+	return nil
+}
+
+func (m *closableModel) Predict(features []float64) (float64, error) {
+	if atomic.LoadUint32(&m.closed) == 1 {
+		return 0, errors.New("predict on closed model")
+	}
+	return m.modelStub.Predict(features)
+}
+
+func TestSwappableModelReloadUnderLoad(t *testing.T) {
+	first := &closableModel{modelStub: modelStub{name: "gen-0", score: 0.1}}
+	sm := NewSwappableModel(first)
+
+	done := make(chan struct{})
+	var failures int32
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if _, err := sm.Predict([]float64{1}); err != nil {
+					atomic.AddInt32(&failures, 1)
+				}
+			}
+		}()
+	}
+
+	generations := make([]*closableModel, 0, 50)
+	generations = append(generations, first)
+	for i := 1; i <= 50; i++ {
+		next := &closableModel{modelStub: modelStub{name: fmt.Sprintf("gen-%d", i), score: 0.1}}
+		generations = append(generations, next)
+		sm.Reload(next)
+	}
+	close(done)
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&failures); got != 0 {
+		t.Fatalf("%d inferences ran on a closed model", got)
+	}
+	// Every retired generation is closed once its inferences drained.
+	for i, gen := range generations[:len(generations)-1] {
+		if atomic.LoadUint32(&gen.closed) != 1 {
+			t.Fatalf("retired generation %d was never closed", i)
+		}
+	}
+	if atomic.LoadUint32(&generations[len(generations)-1].closed) == 1 {
+		t.Fatal("active generation was closed")
+	}
+}
+
+func TestReinitializingModelRecoversDeadSession(t *testing.T) {
+	var loads int32
+	load := func(path string) (Model, error) {
+		if atomic.AddInt32(&loads, 1) == 1 {
+			return &modelStub{name: "dying", err: errors.New("session closed")}, nil
+		}
+		return &modelStub{name: "fresh", score: 0.3}, nil
+	}
+
+	rm, err := NewReinitializingModel("models/m.pb", load)
+	if err != nil {
+		t.Fatalf("NewReinitializingModel: %v", err)
+	}
+	rm.SetReinitPolicy(2, time.Millisecond, 10*time.Millisecond)
+
+	if _, err := rm.Predict([]float64{1}); err == nil {
+		t.Fatal("first failure on the dead session did not surface")
+	}
+	// The second consecutive session error crosses the threshold and
+	// reloads from the cached path.
+	if _, err := rm.Predict([]float64{1}); err == nil {
+		t.Fatal("reinitializing call did not report the failed inference")
+	}
+	score, err := rm.Predict([]float64{1})
+	if err != nil || score != 0.3 {
+		t.Fatalf("post-reinit inference = %g, %v; want 0.3 from the fresh session", score, err)
+	}
+	if got := atomic.LoadInt32(&loads); got != 2 {
+		t.Fatalf("loader ran %d times, want 2", got)
+	}
+}
+
+func TestModelWarmerGatesReadiness(t *testing.T) {
+	model := &modelStub{name: "m", score: 0.1}
+	mw := NewModelWarmer(model, 5)
+	handler := mw.ReadyzHandler()
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("/readyz before warm-up = %d, want 503", rec.Code)
+	}
+
+	mw.WarmUp()
+	if got := atomic.LoadInt32(&model.calls); got != 5 {
+		t.Fatalf("warm-up ran %d dummy inferences, want 5", got)
+	}
+	if !mw.Ready() {
+		t.Fatal("warmer not ready after WarmUp")
+	}
+	rec = httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("/readyz after warm-up = %d, want 200", rec.Code)
+	}
+}
+
+func TestDiagnoseReportsHealthAndFailures(t *testing.T) {
+	healthy := &Diagnostics{
+		Model:     &modelStub{name: "m", score: 0.1},
+		Enforcer:  newTestEnforcer(KYCFull),
+		LogDir:    t.TempDir(),
+		BackupDir: t.TempDir(),
+	}
+	report := healthy.Diagnose(context.Background())
+	if !report.Healthy() {
+		t.Fatalf("healthy installation diagnosed unhealthy: %+v", report.Checks)
+	}
+
+	broken := &Diagnostics{Enforcer: newTestEnforcer(KYCFull)}
+	report = broken.Diagnose(context.Background())
+	if report.Healthy() {
+		t.Fatal("missing model diagnosed healthy")
+	}
+	for _, c := range report.Checks {
+		if c.Name == "model" {
+			if c.Passed || c.Remediation == "" {
+				t.Fatalf("model check = %+v; want a failure with a remediation hint", c)
+			}
+			return
+		}
+	}
+	t.Fatal("report has no model check")
+}
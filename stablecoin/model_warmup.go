@@ -0,0 +1,51 @@
+package stablecoin
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// ModelWarmer runs dummy inferences after a model loads so the first real
+// request doesn't pay the cold-start cost. Readiness only flips once the
+// warm-up completes.
+type ModelWarmer struct {
+	model Model
+	count int
+	ready uint32
+}
+
+// NewModelWarmer prepares a warmer that will run count dummy inferences.
+// A non-positive count defaults to 3.
+func NewModelWarmer(model Model, count int) *ModelWarmer {
+	if count <= 0 {
+		count = 3
+	}
+	return &ModelWarmer{model: model, count: count}
+}
+
+// WarmUp performs the configured number of dummy inferences and then marks
+// the model ready. Inference errors during warm-up are ignored: they still
+// exercise the execution path.
+func (mw *ModelWarmer) WarmUp() {
+	dummy := featureVector(Transaction{ID: "warmup", Origin: "warmup", Recipient: "warmup"})
+	for i := 0; i < mw.count; i++ {
+		mw.model.Predict(dummy)
+	}
+	atomic.StoreUint32(&mw.ready, 1)
+}
+
+// Ready reports whether warm-up has completed.
+func (mw *ModelWarmer) Ready() bool {
+	return atomic.LoadUint32(&mw.ready) == 1
+}
+
+// ReadyzHandler serves GET /readyz: 200 once the model is warm, 503 before.
+func (mw *ModelWarmer) ReadyzHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !mw.Ready() {
+			http.Error(w, "model warming up", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}
+}
@@ -0,0 +1,28 @@
+package stablecoin
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeInput returns the canonical matching form of a field: surrounding
+// whitespace trimmed, Unicode normalized to NFC, and case-folded. This keeps
+// classification stable for inputs like "USDC " or fullwidth variants.
+func NormalizeInput(s string) string {
+	s = strings.TrimSpace(s)
+	s = norm.NFC.String(s)
+	return strings.ToLower(s)
+}
+
+// normalizeTransaction applies NormalizeInput to every string field used in
+// classification. The stored transaction is never mutated; the normalized
+// copy is only used for matching.
+func normalizeTransaction(tx Transaction) Transaction {
+	tx.ID = NormalizeInput(tx.ID)
+	tx.Origin = NormalizeInput(tx.Origin)
+	tx.Recipient = NormalizeInput(tx.Recipient)
+	tx.Currency = NormalizeInput(tx.Currency)
+	tx.Jurisdiction = NormalizeInput(tx.Jurisdiction)
+	return tx
+}
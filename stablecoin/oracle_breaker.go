@@ -0,0 +1,109 @@
+package stablecoin
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOracleCircuitOpen is returned (under the fail-closed policy) while the
+// breaker is open and the oracle is not being consulted.
+var ErrOracleCircuitOpen = errors.New("oracle circuit breaker open")
+
+// BreakerState is the circuit breaker's current disposition.
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// BreakerOracle wraps an IssuanceOracle in a circuit breaker: after
+// maxFailures consecutive failures the breaker opens and the oracle is left
+// alone for the cooldown, during which the configured policy mode decides
+// outcomes locally (DenyUnlessPass fails closed, AllowUnlessFail fails
+// open). After the cooldown one probe request is allowed through; its
+// outcome closes or re-opens the breaker.
+type BreakerOracle struct {
+	inner       IssuanceOracle
+	maxFailures int
+	cooldown    time.Duration
+	mode        PolicyMode
+
+	mu          sync.Mutex
+	state       BreakerState
+	consecutive int
+	openedAt    time.Time
+}
+
+func NewBreakerOracle(inner IssuanceOracle, maxFailures int, cooldown time.Duration, mode PolicyMode) *BreakerOracle {
+	if maxFailures <= 0 {
+		maxFailures = 5
+	}
+	if cooldown <= 0 {
+		cooldown = 30 * time.Second
+	}
+	return &BreakerOracle{
+		inner:       inner,
+		maxFailures: maxFailures,
+		cooldown:    cooldown,
+		mode:        mode,
+	}
+}
+
+// State reports the breaker's current disposition for dashboards and
+// self-tests.
+func (bo *BreakerOracle) State() BreakerState {
+	bo.mu.Lock()
+	defer bo.mu.Unlock()
+	if bo.state == BreakerOpen && time.Since(bo.openedAt) >= bo.cooldown {
+		return BreakerHalfOpen
+	}
+	return bo.state
+}
+
+func (bo *BreakerOracle) ApproveIssuance(ctx context.Context, stablecoin, recipient string, amount float64) error {
+	bo.mu.Lock()
+	if bo.state == BreakerOpen {
+		if time.Since(bo.openedAt) < bo.cooldown {
+			mode := bo.mode
+			bo.mu.Unlock()
+			if mode == AllowUnlessFail {
+				return nil
+			}
+			return ErrOracleCircuitOpen
+		}
+		// Cooldown has passed: let this request probe the oracle.
+		bo.state = BreakerHalfOpen
+	}
+	bo.mu.Unlock()
+
+	err := bo.inner.ApproveIssuance(ctx, stablecoin, recipient, amount)
+
+	bo.mu.Lock()
+	defer bo.mu.Unlock()
+	if err == nil {
+		bo.state = BreakerClosed
+		bo.consecutive = 0
+		return nil
+	}
+	bo.consecutive++
+	if bo.state == BreakerHalfOpen || bo.consecutive >= bo.maxFailures {
+		bo.state = BreakerOpen
+		bo.openedAt = time.Now()
+	}
+	return err
+}
@@ -0,0 +1,56 @@
+package stablecoin
+
+import "math"
+
+// OutputInterpreter maps a model's raw output to a contamination verdict.
+// Models differ: some emit probabilities, some logits needing a sigmoid,
+// some inverted labels where low means contaminated. The interpreter makes
+// that mapping explicit configuration instead of a hardcoded `> 0.5`.
+type OutputInterpreter struct {
+	// Threshold is compared against the (possibly transformed) score.
+	Threshold float64
+	// ApplySigmoid squashes a logit output into (0, 1) before comparing.
+	ApplySigmoid bool
+	// Inverted flips the comparison for models where a low score means
+	// contaminated.
+	Inverted bool
+}
+
+// defaultOutputInterpreter preserves the historical probability-at-0.5
+// interpretation.
+var defaultOutputInterpreter = OutputInterpreter{Threshold: 0.5}
+
+// Score returns the transformed score the threshold is compared against.
+func (oi OutputInterpreter) Score(raw float64) float64 {
+	if oi.ApplySigmoid {
+		return 1 / (1 + math.Exp(-raw))
+	}
+	return raw
+}
+
+// Contaminated maps a raw model output to a verdict.
+func (oi OutputInterpreter) Contaminated(raw float64) bool {
+	score := oi.Score(raw)
+	if oi.Inverted {
+		return score < oi.Threshold
+	}
+	return score >= oi.Threshold
+}
+
+// SetOutputInterpreter configures how the active model's raw output is read.
+func (cc *ContaminationChecker) SetOutputInterpreter(oi OutputInterpreter) {
+	cc.mu.Lock()
+	cc.interpret = &oi
+	cc.mu.Unlock()
+}
+
+// outputInterpreter returns the configured interpreter, or the historical
+// default when none is set.
+func (cc *ContaminationChecker) outputInterpreter() OutputInterpreter {
+	cc.mu.RLock()
+	defer cc.mu.RUnlock()
+	if cc.interpret != nil {
+		return *cc.interpret
+	}
+	return defaultOutputInterpreter
+}
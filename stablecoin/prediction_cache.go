@@ -0,0 +1,110 @@
+package stablecoin
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type predictionCacheEntry struct {
+	key   string
+	score float64
+	at    time.Time
+}
+
+// predictionCache is a TTL'd LRU from feature string to model output, so
+// identical feature inputs skip repeated inference.
+type predictionCache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu    sync.Mutex
+	lru   *list.List
+	cache map[string]*list.Element
+}
+
+func newPredictionCache(maxEntries int, ttl time.Duration) *predictionCache {
+	if maxEntries <= 0 {
+		maxEntries = 1024
+	}
+	return &predictionCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		lru:        list.New(),
+		cache:      make(map[string]*list.Element),
+	}
+}
+
+func (pc *predictionCache) get(key string) (float64, bool) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	el, ok := pc.cache[key]
+	if !ok {
+		return 0, false
+	}
+	entry := el.Value.(*predictionCacheEntry)
+	if pc.ttl > 0 && time.Since(entry.at) >= pc.ttl {
+		pc.lru.Remove(el)
+		delete(pc.cache, key)
+		return 0, false
+	}
+	pc.lru.MoveToFront(el)
+	return entry.score, true
+}
+
+func (pc *predictionCache) put(key string, score float64) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	if _, ok := pc.cache[key]; ok {
+		return
+	}
+	el := pc.lru.PushFront(&predictionCacheEntry{key: key, score: score, at: time.Now()})
+	pc.cache[key] = el
+	for pc.lru.Len() > pc.maxEntries {
+		oldest := pc.lru.Back()
+		pc.lru.Remove(oldest)
+		delete(pc.cache, oldest.Value.(*predictionCacheEntry).key)
+	}
+}
+
+func (pc *predictionCache) clear() {
+	pc.mu.Lock()
+	pc.lru.Init()
+	pc.cache = make(map[string]*list.Element)
+	pc.mu.Unlock()
+}
+
+// SetPredictionCache memoizes model outputs keyed by the transaction's
+// feature string, bounded by size and TTL. Swapping the model (SetModel)
+// invalidates the cache. A non-positive size disables caching.
+func (cc *ContaminationChecker) SetPredictionCache(size int, ttl time.Duration) {
+	cc.mu.Lock()
+	if size <= 0 {
+		cc.predCache = nil
+	} else {
+		cc.predCache = newPredictionCache(size, ttl)
+	}
+	cc.mu.Unlock()
+}
+
+// predict runs the model for the transaction, consulting the prediction
+// cache when one is configured.
+func (cc *ContaminationChecker) predict(model Model, tx Transaction) (float64, error) {
+	cc.mu.RLock()
+	cache := cc.predCache
+	cc.mu.RUnlock()
+	if cache == nil {
+		return predictChecked(model, featureVector(tx))
+	}
+
+	key := Features(tx)
+	if score, ok := cache.get(key); ok {
+		return score, nil
+	}
+	score, err := predictChecked(model, featureVector(tx))
+	if err != nil {
+		return 0, err
+	}
+	cache.put(key, score)
+	return score, nil
+}
@@ -0,0 +1,38 @@
+package stablecoin
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// All non-cryptographic randomness (retry jitter, sampling) flows through
+// this single injectable source, so tests can seed it and replay identical
+// decisions. Cryptographic material (salts, nonces, tokens) deliberately
+// does not use it and stays on crypto/rand.
+var (
+	randMu  sync.Mutex
+	randSrc = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// SetRandSource replaces the pseudo-random source behind jitter and
+// sampling decisions. Seed it with a fixed value for deterministic tests.
+func SetRandSource(src rand.Source) {
+	randMu.Lock()
+	randSrc = rand.New(src)
+	randMu.Unlock()
+}
+
+// randInt63n draws a uniform int64 in [0, n) from the injected source.
+func randInt63n(n int64) int64 {
+	randMu.Lock()
+	defer randMu.Unlock()
+	return randSrc.Int63n(n)
+}
+
+// randFloat64 draws a uniform float64 in [0, 1) from the injected source.
+func randFloat64() float64 {
+	randMu.Lock()
+	defer randMu.Unlock()
+	return randSrc.Float64()
+}
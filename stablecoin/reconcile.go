@@ -0,0 +1,46 @@
+package stablecoin
+
+import "time"
+
+// ReconcileReport summarizes how the converter journal lines up against the
+// ledger over a window.
+type ReconcileReport struct {
+	Matched           int
+	OrphanConversions []ConversionRecord // journal records with no ledger entry
+	OrphanEntries     []LedgerEntry      // ledger entries with no journal record
+}
+
+// ReconcileConversions matches journal records to ledger entries by receipt
+// hash within [from, to] and reports unmatched records on either side.
+// Ledger entries for conversions carry the receipt hash as their data.
+func (c *Converter) ReconcileConversions(l *Ledger, from, to time.Time) ReconcileReport {
+	var report ReconcileReport
+
+	entriesByHash := make(map[string]LedgerEntry)
+	for _, e := range l.Entries() {
+		if e.Timestamp.Before(from) || e.Timestamp.After(to) {
+			continue
+		}
+		entriesByHash[e.Data] = e
+	}
+
+	matchedHashes := make(map[string]bool)
+	for _, rec := range c.Journal() {
+		if rec.Timestamp.Before(from) || rec.Timestamp.After(to) {
+			continue
+		}
+		if _, ok := entriesByHash[rec.ReceiptHash]; ok {
+			report.Matched++
+			matchedHashes[rec.ReceiptHash] = true
+		} else {
+			report.OrphanConversions = append(report.OrphanConversions, rec)
+		}
+	}
+
+	for hash, e := range entriesByHash {
+		if !matchedHashes[hash] {
+			report.OrphanEntries = append(report.OrphanEntries, e)
+		}
+	}
+	return report
+}
@@ -0,0 +1,82 @@
+package stablecoin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+)
+
+// RedactionMode says what happens to a redacted field's value in logs and
+// audit records. Enforcement itself always sees the real value; redaction
+// applies only at the observability boundary.
+type RedactionMode int
+
+const (
+	// RedactHash replaces the value with a SHA-256 digest, keeping
+	// join-ability across records without exposing the value.
+	RedactHash RedactionMode = iota
+	// RedactMask keeps a two-character prefix and masks the rest.
+	RedactMask
+	// RedactDrop removes the value entirely.
+	RedactDrop
+)
+
+var (
+	redactionMu     sync.RWMutex
+	redactionPolicy = map[string]RedactionMode{}
+)
+
+// SetRedactionPolicy marks a transaction field ("id", "origin",
+// "recipient", "currency", "jurisdiction") for redaction before any log or
+// audit write. Calling with no fields clears the policy.
+func SetRedactionPolicy(policy map[string]RedactionMode) {
+	copied := make(map[string]RedactionMode, len(policy))
+	for k, v := range policy {
+		copied[k] = v
+	}
+	redactionMu.Lock()
+	redactionPolicy = copied
+	redactionMu.Unlock()
+}
+
+func redactValue(mode RedactionMode, value string) string {
+	if value == "" {
+		return ""
+	}
+	switch mode {
+	case RedactMask:
+		if len(value) <= 2 {
+			return "***"
+		}
+		return value[:2] + strings.Repeat("*", len(value)-2)
+	case RedactDrop:
+		return ""
+	default:
+		sum := sha256.Sum256([]byte(value))
+		return "sha256:" + hex.EncodeToString(sum[:8])
+	}
+}
+
+// redactField applies the configured policy to one named field, returning
+// the value unchanged when the field is not under policy.
+func redactField(field, value string) string {
+	redactionMu.RLock()
+	mode, ok := redactionPolicy[field]
+	redactionMu.RUnlock()
+	if !ok {
+		return value
+	}
+	return redactValue(mode, value)
+}
+
+// RedactTransaction returns a copy with the configured fields redacted, for
+// use anywhere a transaction is written to logs or audit trails.
+func RedactTransaction(tx Transaction) Transaction {
+	tx.ID = redactField("id", tx.ID)
+	tx.Origin = redactField("origin", tx.Origin)
+	tx.Recipient = redactField("recipient", tx.Recipient)
+	tx.Currency = redactField("currency", tx.Currency)
+	tx.Jurisdiction = redactField("jurisdiction", tx.Jurisdiction)
+	return tx
+}
@@ -0,0 +1,286 @@
+package stablecoin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrExpired is returned when a transaction's ValidUntil lies in the past
+// at enforcement time.
+var ErrExpired = errors.New("transaction expired")
+
+// ErrMissingJurisdiction is returned when jurisdictions are required and a
+// transaction arrives without a known one.
+var ErrMissingJurisdiction = errors.New("transaction jurisdiction missing or unknown")
+
+// ErrCurrencyNotAllowed is returned when a transaction's currency is not on
+// the configured allow-list.
+var ErrCurrencyNotAllowed = errors.New("transaction currency not allowed")
+
+// EnforcementResult is the outcome of running a transaction through the
+// regulatory enforcer.
+type EnforcementResult struct {
+	Allowed bool
+	Reason  string
+	// Monitored marks a transaction admitted under partial KYC that
+	// should receive elevated monitoring.
+	Monitored bool
+}
+
+// PolicyMode controls how the enforcer treats a transaction whose checks
+// come back undetermined (for example, no rule covers its shape).
+type PolicyMode int
+
+const (
+	// DenyUnlessPass rejects anything that did not affirmatively pass.
+	DenyUnlessPass PolicyMode = iota
+	// AllowUnlessFail admits anything that did not affirmatively fail.
+	AllowUnlessFail
+)
+
+// RegulatoryEnforcer decides whether a transaction may proceed. KYC status
+// is established by consulting the jurisdiction's registered provider rather
+// than trusting a flag supplied by the caller.
+type RegulatoryEnforcer struct {
+	kyc        *KYCRegistry
+	kycTimeout time.Duration
+	mode       PolicyMode
+
+	// lowKYCCeiling, when positive, admits Basic-level users up to this
+	// amount with elevated monitoring; larger amounts require Full KYC.
+	lowKYCCeiling float64
+	notifier      *WebhookNotifier
+
+	sink         DecisionSink
+	counters     enforcerCounters
+	tarpit       *Tarpit
+	destinations DestinationVerifier
+	geofence     *GeoFence
+
+	requireJurisdiction bool
+	allowedCurrencies   map[string]bool // nil disables the allow-list
+
+	approvalThreshold float64
+	approvalMu        sync.Mutex
+	pendingApprovals  map[string]*pendingApproval
+}
+
+func NewRegulatoryEnforcer(kyc *KYCRegistry) *RegulatoryEnforcer {
+	return &RegulatoryEnforcer{
+		kyc:        kyc,
+		kycTimeout: 5 * time.Second,
+	}
+}
+
+// SetKYCTimeout sets the deadline applied to each provider lookup.
+func (re *RegulatoryEnforcer) SetKYCTimeout(d time.Duration) {
+	re.kycTimeout = d
+}
+
+// SetLowKYCCeiling enables partial-KYC acceptance: Basic-level users may
+// transact up to ceiling, flagged for monitoring; above it Full KYC is
+// required. Monitored admissions are emitted to the notifier when one is
+// set. A zero ceiling restores the flat Basic-level requirement.
+func (re *RegulatoryEnforcer) SetLowKYCCeiling(ceiling float64, notifier *WebhookNotifier) {
+	re.lowKYCCeiling = ceiling
+	re.notifier = notifier
+}
+
+// SetTarpit enables progressive rejection delays for abusive origins. Nil
+// disables tarpitting.
+func (re *RegulatoryEnforcer) SetTarpit(t *Tarpit) {
+	re.tarpit = t
+}
+
+// SetDecisionSink routes every decision to the given sink (use a MultiSink
+// to tee). Without a sink, decisions are not recorded anywhere.
+func (re *RegulatoryEnforcer) SetDecisionSink(sink DecisionSink) {
+	re.sink = sink
+}
+
+// SetAllowedCurrencies restricts transactions to the given currencies;
+// anything else is rejected with ErrCurrencyNotAllowed. Calling with no
+// currencies disables the allow-list.
+func (re *RegulatoryEnforcer) SetAllowedCurrencies(currencies ...string) {
+	if len(currencies) == 0 {
+		re.allowedCurrencies = nil
+		return
+	}
+	allowed := make(map[string]bool, len(currencies))
+	for _, c := range currencies {
+		allowed[c] = true
+	}
+	re.allowedCurrencies = allowed
+}
+
+// SetRequireJurisdiction, when enabled, rejects any transaction without a
+// jurisdiction covered by a registered KYC provider instead of resolving it
+// through the policy mode. Off by default.
+func (re *RegulatoryEnforcer) SetRequireJurisdiction(on bool) {
+	re.requireJurisdiction = on
+}
+
+// SetPolicyMode selects how undetermined results are resolved. The default
+// is DenyUnlessPass.
+func (re *RegulatoryEnforcer) SetPolicyMode(mode PolicyMode) {
+	re.mode = mode
+}
+
+// resolveUndetermined turns an ambiguous check outcome into a decision
+// according to the configured policy mode.
+func (re *RegulatoryEnforcer) resolveUndetermined(reason string) EnforcementResult {
+	if re.mode == AllowUnlessFail {
+		return EnforcementResult{Allowed: true, Reason: reason + " (allowed by policy mode)"}
+	}
+	return EnforcementResult{Allowed: false, Reason: reason + " (denied by policy mode)"}
+}
+
+// EnforceTransaction checks the transaction against regulatory requirements.
+// Any provider failure fails closed: the transaction is rejected.
+func (re *RegulatoryEnforcer) EnforceTransaction(ctx context.Context, tx Transaction) (EnforcementResult, error) {
+	result, err := re.enforce(ctx, tx)
+	// Rejections surfaced as typed errors (expiry, missing jurisdiction)
+	// carry a populated result and are decisions like any other: they are
+	// counted and published, not just returned.
+	decided := err == nil || result.Reason != ""
+	if decided {
+		re.counters.record(result)
+	}
+	if re.tarpit != nil && (err != nil || !result.Allowed) && result.Reason != ReasonPendingApproval {
+		re.tarpit.RecordRejection(tx.Origin)
+		if waitErr := re.tarpit.Wait(ctx, tx.Origin); waitErr != nil {
+			return result, waitErr
+		}
+	}
+	if decided && re.sink != nil {
+		re.sink.Publish(DecisionRecord{
+			SchemaVersion: DecisionSchemaVersion,
+			TransactionID: redactField("id", tx.ID),
+			Allowed:       result.Allowed,
+			Reason:        result.Reason,
+			Monitored:     result.Monitored,
+			Timestamp:     time.Now(),
+			Tags:          tx.Tags,
+		})
+	}
+	return result, err
+}
+
+func (re *RegulatoryEnforcer) enforce(ctx context.Context, tx Transaction) (EnforcementResult, error) {
+	// Expiry is checked first, alongside the replay window: a transaction
+	// presented after its ValidUntil is rejected outright.
+	if tx.ValidUntil != 0 && time.Now().Unix() > tx.ValidUntil {
+		return EnforcementResult{Allowed: false, Reason: ErrExpired.Error()}, ErrExpired
+	}
+
+	if re.allowedCurrencies != nil && !re.allowedCurrencies[tx.Currency] {
+		return EnforcementResult{
+			Allowed: false,
+			Reason:  fmt.Sprintf("%v: %s", ErrCurrencyNotAllowed, tx.Currency),
+		}, nil
+	}
+
+	if re.geofence != nil {
+		if err := re.geofence.Check(ctx, tx.Origin); err != nil {
+			return EnforcementResult{Allowed: false, Reason: err.Error()}, nil
+		}
+	}
+
+	if result, rejected := re.checkDestination(ctx, tx); rejected {
+		return result, nil
+	}
+
+	if re.requireJurisdiction && tx.Jurisdiction == "" {
+		return EnforcementResult{Allowed: false, Reason: ErrMissingJurisdiction.Error()}, ErrMissingJurisdiction
+	}
+
+	provider, err := re.kyc.Lookup(tx.Jurisdiction)
+	if err != nil {
+		if re.requireJurisdiction {
+			// With jurisdictions required, an uncovered one is a hard
+			// rejection rather than an undetermined outcome.
+			return EnforcementResult{
+				Allowed: false,
+				Reason:  fmt.Sprintf("%v: %s", ErrMissingJurisdiction, tx.Jurisdiction),
+			}, ErrMissingJurisdiction
+		}
+		// No provider covers this jurisdiction: the KYC status is
+		// undetermined rather than failed.
+		return re.resolveUndetermined(err.Error()), nil
+	}
+
+	verifyCtx := ctx
+	if re.kycTimeout > 0 {
+		var cancel context.CancelFunc
+		verifyCtx, cancel = context.WithTimeout(ctx, re.kycTimeout)
+		defer cancel()
+	}
+
+	level, err := provider.Verify(verifyCtx, tx.Origin, tx.Jurisdiction)
+	if err != nil {
+		// Fail closed: an unreachable or erroring provider never admits
+		// a transaction.
+		return EnforcementResult{
+			Allowed: false,
+			Reason:  fmt.Sprintf("KYC verification failed: %v", err),
+		}, nil
+	}
+	if level < KYCBasic {
+		return EnforcementResult{Allowed: false, Reason: "KYC level insufficient"}, nil
+	}
+
+	if re.lowKYCCeiling > 0 && level < KYCFull {
+		if tx.Amount > re.lowKYCCeiling {
+			return EnforcementResult{
+				Allowed: false,
+				Reason:  fmt.Sprintf("amount %g exceeds low-KYC ceiling %g; full KYC required", tx.Amount, re.lowKYCCeiling),
+			}, nil
+		}
+		result := EnforcementResult{
+			Allowed:   true,
+			Reason:    "passed under low-KYC ceiling",
+			Monitored: true,
+		}
+		if re.notifier != nil {
+			re.notifier.Enqueue([]byte(fmt.Sprintf(
+				`{"event":"monitored_transaction","id":%q,"origin":%q,"amount":%g}`,
+				tx.ID, tx.Origin, tx.Amount)))
+		}
+		return result, nil
+	}
+
+	// High-value transactions that passed every check still wait for a
+	// second approver before taking effect.
+	if re.approvalThreshold > 0 && tx.Amount > re.approvalThreshold {
+		return re.holdForApproval(tx), nil
+	}
+
+	return EnforcementResult{Allowed: true, Reason: "passed"}, nil
+}
+
+// EnforceProto unmarshals a protobuf-encoded Transaction and enforces it.
+// ID, origin and recipient are required.
+func (re *RegulatoryEnforcer) EnforceProto(ctx context.Context, data []byte) (EnforcementResult, error) {
+	tx, err := UnmarshalTransactionProto(data)
+	if err != nil {
+		return EnforcementResult{}, fmt.Errorf("decoding transaction: %v", err)
+	}
+	tx, err = NormalizeAndValidate(tx)
+	if err != nil {
+		return EnforcementResult{}, err
+	}
+	return re.EnforceTransaction(ctx, tx)
+}
+
+// EnforceProtoBytes is the wire-to-wire sibling of EnforceProto: it takes a
+// protobuf Transaction and returns a protobuf EnforcementResult.
+func (re *RegulatoryEnforcer) EnforceProtoBytes(ctx context.Context, data []byte) ([]byte, error) {
+	result, err := re.EnforceProto(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return result.MarshalProto(), nil
+}
@@ -0,0 +1,103 @@
+package stablecoin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ReserveAttestation is a signed statement of the reserve backing a
+// stablecoin at a point in time.
+type ReserveAttestation struct {
+	Stablecoin string    `json:"stablecoin"`
+	Amount     float64   `json:"amount"`
+	Timestamp  time.Time `json:"timestamp"`
+	Signature  string    `json:"signature"`
+}
+
+// AttestationProvider produces the current reserve attestation for a
+// stablecoin type.
+type AttestationProvider interface {
+	Attest(ctx context.Context, stablecoin string) (ReserveAttestation, error)
+}
+
+type cachedAttestation struct {
+	att     ReserveAttestation
+	fetched time.Time
+}
+
+// AttestationService serves the latest reserve attestation per stablecoin,
+// caching provider responses for a configurable TTL.
+type AttestationService struct {
+	ttl time.Duration
+
+	mu        sync.Mutex
+	providers map[string]AttestationProvider
+	cache     map[string]cachedAttestation
+	now       func() time.Time
+}
+
+func NewAttestationService(ttl time.Duration) *AttestationService {
+	return &AttestationService{
+		ttl:       ttl,
+		providers: make(map[string]AttestationProvider),
+		cache:     make(map[string]cachedAttestation),
+		now:       time.Now,
+	}
+}
+
+// Register installs the attestation provider for a stablecoin type.
+func (as *AttestationService) Register(stablecoin string, p AttestationProvider) {
+	as.mu.Lock()
+	as.providers[stablecoin] = p
+	as.mu.Unlock()
+}
+
+// Latest returns the attestation for the stablecoin, consulting the provider
+// only when the cached copy is older than the TTL.
+func (as *AttestationService) Latest(ctx context.Context, stablecoin string) (ReserveAttestation, error) {
+	as.mu.Lock()
+	p, ok := as.providers[stablecoin]
+	if !ok {
+		as.mu.Unlock()
+		return ReserveAttestation{}, fmt.Errorf("no attestation provider for stablecoin %q", stablecoin)
+	}
+	if c, ok := as.cache[stablecoin]; ok && as.now().Sub(c.fetched) < as.ttl {
+		as.mu.Unlock()
+		return c.att, nil
+	}
+	as.mu.Unlock()
+
+	att, err := p.Attest(ctx, stablecoin)
+	if err != nil {
+		return ReserveAttestation{}, err
+	}
+
+	as.mu.Lock()
+	as.cache[stablecoin] = cachedAttestation{att: att, fetched: as.now()}
+	as.mu.Unlock()
+	return att, nil
+}
+
+// Handler exposes the latest attestation over HTTP:
+//
+//	GET /reserve/attestation?stablecoin=<type>
+func (as *AttestationService) Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		stablecoin := r.URL.Query().Get("stablecoin")
+		if stablecoin == "" {
+			http.Error(w, "missing stablecoin parameter", http.StatusBadRequest)
+			return
+		}
+		att, err := as.Latest(r.Context(), stablecoin)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(att)
+	}
+}
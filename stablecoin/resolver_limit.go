@@ -0,0 +1,68 @@
+package stablecoin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// ErrResolverBusy is returned when a resolver slot cannot be acquired
+// before the queue timeout.
+var ErrResolverBusy = errors.New("resolver concurrency limit reached")
+
+// ResolverLimiter bounds how many resolutions (for example stablecoinData)
+// run concurrently, so query floods cannot overwhelm the backing model.
+// Waiting requests respect their context deadline and a queue timeout.
+type ResolverLimiter struct {
+	sem          chan struct{}
+	queueTimeout time.Duration
+}
+
+func NewResolverLimiter(limit int, queueTimeout time.Duration) *ResolverLimiter {
+	if limit <= 0 {
+		limit = 16
+	}
+	return &ResolverLimiter{
+		sem:          make(chan struct{}, limit),
+		queueTimeout: queueTimeout,
+	}
+}
+
+// Acquire blocks until a slot is free, the context is done, or the queue
+// timeout elapses (returning ErrResolverBusy).
+func (rl *ResolverLimiter) Acquire(ctx context.Context) error {
+	var timeout <-chan time.Time
+	if rl.queueTimeout > 0 {
+		timer := time.NewTimer(rl.queueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case rl.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timeout:
+		return ErrResolverBusy
+	}
+}
+
+// Release frees a slot acquired with Acquire.
+func (rl *ResolverLimiter) Release() {
+	<-rl.sem
+}
+
+// Middleware applies the limiter to an HTTP resolver endpoint, answering
+// 503 (the GraphQL transport equivalent of a server-busy error) when no
+// slot frees up in time.
+func (rl *ResolverLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := rl.Acquire(r.Context()); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		defer rl.Release()
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,96 @@
+package stablecoin
+
+import (
+	"sync"
+	"time"
+)
+
+// ValidationRLAgent evolves validation rules from observed outcomes. Each
+// Learn call feeds a structured sample to the feature store so training
+// data survives restarts instead of living in wiped in-memory logs.
+type ValidationRLAgent struct {
+	mu    sync.Mutex
+	rules []string
+	store FeatureStore
+	audit AuditSink
+
+	// Staged-approval mode: proposals wait in pending until approved.
+	requireApproval bool
+	approver        RuleApprover
+	pending         []string
+
+	// Persistence cap: SaveRules evicts LRU-by-trigger past maxPersisted.
+	maxPersisted int
+	ruleTouched  map[string]time.Time
+}
+
+func NewValidationRLAgent(store FeatureStore) *ValidationRLAgent {
+	return &ValidationRLAgent{store: store}
+}
+
+// SetAuditSink routes rule-evolution audit records to the sink. Without one
+// evolutions still happen but leave no trail.
+func (a *ValidationRLAgent) SetAuditSink(sink AuditSink) {
+	a.mu.Lock()
+	a.audit = sink
+	a.mu.Unlock()
+}
+
+// EvolveRules applies mutate to the rule set, recording timestamp, trigger,
+// and the before/after snapshots to the audit sink. With staged approval
+// enabled (RequireApproval), the mutation is held as a pending proposal
+// instead of taking effect immediately.
+func (a *ValidationRLAgent) EvolveRules(trigger string, mutate func(rules []string) []string) error {
+	a.mu.Lock()
+	before := make([]string, len(a.rules))
+	copy(before, a.rules)
+	proposed := mutate(a.rules)
+	if a.requireApproval && !a.stageProposal(before, proposed) {
+		a.mu.Unlock()
+		return nil
+	}
+	a.rules = proposed
+	a.touchRules(proposed, time.Now())
+	if a.maxPersisted > 0 && len(a.rules) > a.maxPersisted {
+		debugf("rule evolution %q grew the set to %d rules, above the persistence cap %d", trigger, len(a.rules), a.maxPersisted)
+	}
+	after := make([]string, len(a.rules))
+	copy(after, a.rules)
+	audit := a.audit
+	a.mu.Unlock()
+
+	return a.auditEvolution(audit, trigger, before, after)
+}
+
+// Learn records the outcome of enforcing a transaction and, when the reward
+// is strongly negative, derives a candidate rule from the transaction's
+// features.
+func (a *ValidationRLAgent) Learn(tx Transaction, label string, reward float64) error {
+	if a.store != nil {
+		sample := TrainingSample{
+			Features:  Features(tx),
+			Label:     label,
+			Reward:    reward,
+			Timestamp: time.Now(),
+		}
+		if err := a.store.Record(sample); err != nil {
+			return err
+		}
+	}
+
+	if reward < -0.5 {
+		return a.EvolveRules("negative reward for "+tx.ID, func(rules []string) []string {
+			return append(rules, "deny origin="+tx.Origin)
+		})
+	}
+	return nil
+}
+
+// Rules returns a copy of the agent's current rule set.
+func (a *ValidationRLAgent) Rules() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	out := make([]string, len(a.rules))
+	copy(out, a.rules)
+	return out
+}
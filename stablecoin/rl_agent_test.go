@@ -0,0 +1,165 @@
+package stablecoin
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRuleEvolutionIsAudited(t *testing.T) {
+	audit := &memAuditSink{}
+	agent := NewValidationRLAgent(nil)
+	agent.SetAuditSink(audit)
+
+	if err := agent.EvolveRules("manual tightening", func(rules []string) []string {
+		return append(rules, "deny origin=eve")
+	}); err != nil {
+		t.Fatalf("EvolveRules: %v", err)
+	}
+
+	if len(audit.records) != 1 {
+		t.Fatalf("audit received %d records, want 1", len(audit.records))
+	}
+	rec := audit.records[0]
+	if rec.Kind != "rule_evolution" || rec.Details["trigger"] != "manual tightening" {
+		t.Fatalf("audit record = %+v", rec)
+	}
+	if before, _ := rec.Details["rules_before"].([]string); len(before) != 0 {
+		t.Fatalf("rules_before = %v, want the empty starting set", before)
+	}
+	after, _ := rec.Details["rules_after"].([]string)
+	if !reflect.DeepEqual(after, []string{"deny origin=eve"}) {
+		t.Fatalf("rules_after = %v, want the evolved set", after)
+	}
+}
+
+func TestLearnRecordsSamplesAndEvolvesOnNegativeReward(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "samples.jsonl")
+	agent := NewValidationRLAgent(NewFileFeatureStore(path))
+
+	tx := Transaction{ID: "tx-1", Origin: "eve", Recipient: "bob", Currency: "PI", Amount: 5}
+	if err := agent.Learn(tx, "rejected", -1); err != nil {
+		t.Fatalf("Learn: %v", err)
+	}
+	if err := agent.Learn(tx, "allowed", 1); err != nil {
+		t.Fatalf("Learn: %v", err)
+	}
+
+	if got := agent.Rules(); !reflect.DeepEqual(got, []string{"deny origin=eve"}) {
+		t.Fatalf("rules after learning = %v; only the negative reward should evolve one", got)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading feature store: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("feature store holds %d samples, want 2", len(lines))
+	}
+	var sample TrainingSample
+	if err := json.Unmarshal([]byte(lines[0]), &sample); err != nil {
+		t.Fatalf("parsing recorded sample: %v", err)
+	}
+	if sample.Label != "rejected" || sample.Reward != -1 || sample.Features != Features(tx) {
+		t.Fatalf("recorded sample = %+v", sample)
+	}
+}
+
+func TestProposedRulesInactiveUntilApproved(t *testing.T) {
+	audit := &memAuditSink{}
+	agent := NewValidationRLAgent(nil)
+	agent.SetAuditSink(audit)
+	agent.RequireApproval(nil) // every proposal waits for an operator
+
+	if err := agent.EvolveRules("candidate rule", func(rules []string) []string {
+		return append(rules, "deny origin=eve")
+	}); err != nil {
+		t.Fatalf("EvolveRules: %v", err)
+	}
+	if got := agent.Rules(); len(got) != 0 {
+		t.Fatalf("unapproved proposal already active: %v", got)
+	}
+	if got := agent.PendingRules(); !reflect.DeepEqual(got, []string{"deny origin=eve"}) {
+		t.Fatalf("PendingRules() = %v, want the staged proposal", got)
+	}
+	// No audit record until the set actually changes.
+	if len(audit.records) != 0 {
+		t.Fatalf("staging wrote %d audit records, want 0", len(audit.records))
+	}
+
+	if err := agent.ApprovePending(); err != nil {
+		t.Fatalf("ApprovePending: %v", err)
+	}
+	if got := agent.Rules(); !reflect.DeepEqual(got, []string{"deny origin=eve"}) {
+		t.Fatalf("rules after approval = %v", got)
+	}
+	if agent.PendingRules() != nil {
+		t.Fatal("approved proposal still pending")
+	}
+	if len(audit.records) != 1 || audit.records[0].Kind != "rule_evolution" {
+		t.Fatalf("approval audit = %+v, want one rule_evolution record", audit.records)
+	}
+	if err := agent.ApprovePending(); err == nil {
+		t.Fatal("ApprovePending succeeded with nothing staged")
+	}
+
+	// Rejected proposals vanish without touching the active set.
+	agent.EvolveRules("bad idea", func(rules []string) []string {
+		return append(rules, "deny origin=everyone")
+	})
+	agent.RejectPending()
+	if agent.PendingRules() != nil || len(agent.Rules()) != 1 {
+		t.Fatalf("rejection left rules=%v pending=%v", agent.Rules(), agent.PendingRules())
+	}
+
+	// An approver can wave proposals through immediately.
+	agent.RequireApproval(func(before, after []string) bool { return true })
+	agent.EvolveRules("pre-approved", func(rules []string) []string {
+		return append(rules, "deny origin=mallory")
+	})
+	if got := agent.Rules(); len(got) != 2 {
+		t.Fatalf("auto-approved proposal not applied: %v", got)
+	}
+}
+
+func TestPersistedRulesNeverExceedCap(t *testing.T) {
+	agent := NewValidationRLAgent(nil)
+	agent.SetMaxPersistedRules(2)
+	for _, rule := range []string{"deny origin=a", "deny origin=b", "deny origin=c"} {
+		rule := rule
+		agent.EvolveRules("growth", func(rules []string) []string {
+			return append(rules, rule)
+		})
+	}
+
+	path := filepath.Join(t.TempDir(), "rules.json")
+	if err := agent.SaveRules(path); err != nil {
+		t.Fatalf("SaveRules: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading snapshot: %v", err)
+	}
+	var persisted []string
+	if err := json.Unmarshal(data, &persisted); err != nil {
+		t.Fatalf("parsing snapshot: %v", err)
+	}
+	if len(persisted) != 2 {
+		t.Fatalf("persisted %d rules with cap 2: %v", len(persisted), persisted)
+	}
+	// The cap trims the snapshot, not the live set.
+	if got := agent.Rules(); len(got) != 3 {
+		t.Fatalf("live rules = %v, want all 3", got)
+	}
+
+	fresh := NewValidationRLAgent(nil)
+	if err := fresh.LoadRules(path); err != nil {
+		t.Fatalf("LoadRules: %v", err)
+	}
+	if got := fresh.Rules(); !reflect.DeepEqual(got, persisted) {
+		t.Fatalf("reloaded rules = %v, want %v", got, persisted)
+	}
+}
@@ -0,0 +1,90 @@
+package stablecoin
+
+import (
+	"fmt"
+	"time"
+)
+
+// RuleApprover decides whether a proposed rule set may replace the active
+// one. Returning false leaves the proposal pending.
+type RuleApprover func(before, after []string) bool
+
+// RequireApproval switches the agent to staged evolution: EvolveRules no
+// longer applies mutations directly but stores the proposed rule set as
+// pending, activating it only once the approver (or ApprovePending) says
+// yes. A nil approver means proposals wait for an explicit ApprovePending
+// call.
+func (a *ValidationRLAgent) RequireApproval(approve RuleApprover) {
+	a.mu.Lock()
+	a.requireApproval = true
+	a.approver = approve
+	a.mu.Unlock()
+}
+
+// PendingRules returns the proposed rule set awaiting approval, or nil when
+// nothing is staged.
+func (a *ValidationRLAgent) PendingRules() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.pending == nil {
+		return nil
+	}
+	out := make([]string, len(a.pending))
+	copy(out, a.pending)
+	return out
+}
+
+// ApprovePending activates the staged rule set; operators call this through
+// the admin API after reviewing the proposal.
+func (a *ValidationRLAgent) ApprovePending() error {
+	a.mu.Lock()
+	if a.pending == nil {
+		a.mu.Unlock()
+		return fmt.Errorf("no pending rule proposal")
+	}
+	before := make([]string, len(a.rules))
+	copy(before, a.rules)
+	a.rules = a.pending
+	a.pending = nil
+	after := make([]string, len(a.rules))
+	copy(after, a.rules)
+	audit := a.audit
+	a.mu.Unlock()
+
+	return a.auditEvolution(audit, "pending proposal approved", before, after)
+}
+
+// RejectPending discards the staged rule set.
+func (a *ValidationRLAgent) RejectPending() {
+	a.mu.Lock()
+	a.pending = nil
+	a.mu.Unlock()
+}
+
+// stageProposal records a proposed rule set and, when an approver is
+// configured, asks it for an immediate decision. Callers hold a.mu; the
+// returned apply flag tells EvolveRules whether to activate the proposal.
+func (a *ValidationRLAgent) stageProposal(before, proposed []string) bool {
+	if a.approver != nil && a.approver(before, proposed) {
+		return true
+	}
+	a.pending = proposed
+	return false
+}
+
+// auditEvolution writes the before/after snapshot for one rule change.
+func (a *ValidationRLAgent) auditEvolution(audit AuditSink, trigger string, before, after []string) error {
+	if audit == nil {
+		return nil
+	}
+	return audit.WriteAudit(AuditRecord{
+		SchemaVersion: AuditSchemaVersion,
+		Timestamp:     time.Now(),
+		Kind:          "rule_evolution",
+		Details: map[string]interface{}{
+			"trigger":      trigger,
+			"rules_before": before,
+			"rules_after":  after,
+		},
+	})
+}
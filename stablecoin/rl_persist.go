@@ -0,0 +1,87 @@
+package stablecoin
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"time"
+)
+
+// SetMaxPersistedRules caps how many rules SaveRules writes. When the live
+// set exceeds the cap, the rules least recently touched by an evolution are
+// evicted from the persisted snapshot (LRU by trigger time). A non-positive
+// cap persists everything.
+func (a *ValidationRLAgent) SetMaxPersistedRules(n int) {
+	a.mu.Lock()
+	a.maxPersisted = n
+	a.mu.Unlock()
+}
+
+// touchRules stamps the rules in after with the evolution time, keeping the
+// trigger-recency order the persistence cap evicts by. Callers hold a.mu.
+func (a *ValidationRLAgent) touchRules(after []string, at time.Time) {
+	if a.ruleTouched == nil {
+		a.ruleTouched = make(map[string]time.Time)
+	}
+	live := make(map[string]bool, len(after))
+	for _, r := range after {
+		live[r] = true
+		a.ruleTouched[r] = at
+	}
+	for r := range a.ruleTouched {
+		if !live[r] {
+			delete(a.ruleTouched, r)
+		}
+	}
+}
+
+// persistableRules returns the rules to save, applying the cap. Callers
+// hold a.mu.
+func (a *ValidationRLAgent) persistableRules() []string {
+	out := make([]string, len(a.rules))
+	copy(out, a.rules)
+	if a.maxPersisted <= 0 || len(out) <= a.maxPersisted {
+		return out
+	}
+	// Keep the most recently triggered rules; ties keep list order.
+	sort.SliceStable(out, func(i, j int) bool {
+		return a.ruleTouched[out[i]].After(a.ruleTouched[out[j]])
+	})
+	out = out[:a.maxPersisted]
+	return out
+}
+
+// SaveRules persists the rule set as JSON, evicting down to the configured
+// cap so the config file cannot grow unboundedly.
+func (a *ValidationRLAgent) SaveRules(path string) error {
+	a.mu.Lock()
+	rules := a.persistableRules()
+	dropped := len(a.rules) - len(rules)
+	a.mu.Unlock()
+
+	if dropped > 0 {
+		debugf("rule persistence cap: evicting %d least recently triggered rules", dropped)
+	}
+	data, err := json.MarshalIndent(rules, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+// LoadRules replaces the rule set with a persisted snapshot.
+func (a *ValidationRLAgent) LoadRules(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var rules []string
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return err
+	}
+	a.mu.Lock()
+	a.rules = rules
+	a.touchRules(rules, time.Now())
+	a.mu.Unlock()
+	return nil
+}
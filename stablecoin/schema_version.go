@@ -0,0 +1,43 @@
+package stablecoin
+
+import "encoding/json"
+
+// Emitted record schemas are versioned so downstream consumers can evolve
+// independently of this service. The rules:
+//
+//   - Writers always stamp the current version into schema_version.
+//   - Additive changes (new optional fields) do not bump the version;
+//     readers must ignore fields they do not recognize.
+//   - Renames, removals, or semantic changes bump the version, and readers
+//     branch on schema_version to migrate old records.
+//   - A missing schema_version means version 1, the pre-versioning format.
+const (
+	DecisionSchemaVersion = 1
+	AuditSchemaVersion    = 1
+)
+
+// DecodeDecisionRecord parses a serialized decision record, tolerating
+// unknown future fields and defaulting a missing schema_version to 1.
+func DecodeDecisionRecord(data []byte) (DecisionRecord, error) {
+	var rec DecisionRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return DecisionRecord{}, err
+	}
+	if rec.SchemaVersion == 0 {
+		rec.SchemaVersion = 1
+	}
+	return rec, nil
+}
+
+// DecodeAuditRecord parses a serialized audit record under the same
+// compatibility rules as DecodeDecisionRecord.
+func DecodeAuditRecord(data []byte) (AuditRecord, error) {
+	var rec AuditRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return AuditRecord{}, err
+	}
+	if rec.SchemaVersion == 0 {
+		rec.SchemaVersion = 1
+	}
+	return rec, nil
+}
@@ -0,0 +1,85 @@
+package stablecoin
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReasonPendingApproval marks a high-value transaction parked until a
+// second approver signs off.
+const ReasonPendingApproval = "pending secondary approval"
+
+// pendingApproval holds a high-value transaction between the two phases.
+type pendingApproval struct {
+	tx          Transaction
+	requestedAt time.Time
+}
+
+// SetSecondApprovalThreshold requires a second approver for transactions
+// above the threshold: enforcement returns ReasonPendingApproval and the
+// transaction only finalizes through Approve. A zero threshold disables the
+// flow.
+func (re *RegulatoryEnforcer) SetSecondApprovalThreshold(threshold float64) {
+	re.approvalThreshold = threshold
+	if re.pendingApprovals == nil {
+		re.pendingApprovals = make(map[string]*pendingApproval)
+	}
+}
+
+// holdForApproval parks a passing high-value transaction and returns the
+// pending result. Callers have already run every other check.
+func (re *RegulatoryEnforcer) holdForApproval(tx Transaction) EnforcementResult {
+	re.approvalMu.Lock()
+	re.pendingApprovals[tx.ID] = &pendingApproval{tx: tx, requestedAt: time.Now()}
+	re.approvalMu.Unlock()
+	return EnforcementResult{Allowed: false, Reason: ReasonPendingApproval}
+}
+
+// Approve finalizes a transaction held for secondary approval. The approver
+// must be a different identity than the transaction's origin. The published
+// decision records both parties: the originator and the approver.
+func (re *RegulatoryEnforcer) Approve(txID, approver string) (EnforcementResult, error) {
+	re.approvalMu.Lock()
+	pending, ok := re.pendingApprovals[txID]
+	if ok {
+		delete(re.pendingApprovals, txID)
+	}
+	re.approvalMu.Unlock()
+
+	if !ok {
+		return EnforcementResult{}, fmt.Errorf("transaction %s is not pending approval", txID)
+	}
+	if approver == "" || approver == pending.tx.Origin {
+		// Self-approval defeats the control; put the transaction back.
+		re.approvalMu.Lock()
+		re.pendingApprovals[txID] = pending
+		re.approvalMu.Unlock()
+		return EnforcementResult{}, fmt.Errorf("approver must differ from originator %s", pending.tx.Origin)
+	}
+
+	result := EnforcementResult{
+		Allowed: true,
+		Reason:  fmt.Sprintf("passed with secondary approval (origin %s, approver %s)", pending.tx.Origin, approver),
+	}
+	re.counters.record(result)
+	if re.sink != nil {
+		re.sink.Publish(DecisionRecord{
+			SchemaVersion: DecisionSchemaVersion,
+			TransactionID: txID,
+			Allowed:       true,
+			Reason:        result.Reason,
+			Timestamp:     time.Now(),
+			Tags:          pending.tx.Tags,
+		})
+	}
+	return result, nil
+}
+
+// PendingApproval reports whether the transaction is parked awaiting its
+// second approver.
+func (re *RegulatoryEnforcer) PendingApproval(txID string) bool {
+	re.approvalMu.Lock()
+	defer re.approvalMu.Unlock()
+	_, ok := re.pendingApprovals[txID]
+	return ok
+}
@@ -0,0 +1,86 @@
+package stablecoin
+
+import (
+	"strings"
+	"sync"
+)
+
+// Severity ranks how serious a rejection reason is.
+type Severity int
+
+const (
+	SeverityLow Severity = iota
+	SeverityMedium
+	SeverityHigh
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityHigh:
+		return "high"
+	case SeverityMedium:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// SeverityRouter is a DecisionSink that grades each rejection by its reason
+// and routes accordingly: every decision increments a severity-labelled
+// metric, and rejections at or above the alert level are forwarded to the
+// alerting sink. Reasons are matched by prefix because many carry dynamic
+// suffixes (amounts, identities); the longest configured prefix wins, and
+// unmatched reasons default to low.
+type SeverityRouter struct {
+	alerts     DecisionSink
+	alertLevel Severity
+	metrics    *Metrics
+
+	mu       sync.RWMutex
+	prefixes map[string]Severity
+}
+
+func NewSeverityRouter(alerts DecisionSink, alertLevel Severity, metrics *Metrics) *SeverityRouter {
+	if metrics == nil {
+		metrics = DefaultMetrics
+	}
+	return &SeverityRouter{
+		alerts:     alerts,
+		alertLevel: alertLevel,
+		metrics:    metrics,
+		prefixes:   make(map[string]Severity),
+	}
+}
+
+// MapReason assigns a severity to every reason starting with the prefix.
+func (sr *SeverityRouter) MapReason(prefix string, severity Severity) {
+	sr.mu.Lock()
+	sr.prefixes[prefix] = severity
+	sr.mu.Unlock()
+}
+
+// severityOf grades a reason by its longest matching prefix.
+func (sr *SeverityRouter) severityOf(reason string) Severity {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+	best := -1
+	severity := SeverityLow
+	for prefix, s := range sr.prefixes {
+		if strings.HasPrefix(reason, prefix) && len(prefix) > best {
+			best = len(prefix)
+			severity = s
+		}
+	}
+	return severity
+}
+
+func (sr *SeverityRouter) Publish(rec DecisionRecord) error {
+	severity := sr.severityOf(rec.Reason)
+	sr.metrics.Counter("decisions_by_severity_total", map[string]string{
+		"severity": severity.String(),
+	}).Inc()
+	if !rec.Allowed && severity >= sr.alertLevel && sr.alerts != nil {
+		return sr.alerts.Publish(rec)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package stablecoin
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Divergence records one transaction on which the shadow config decided
+// differently from the active one.
+type Divergence struct {
+	TransactionID string
+	ActiveAllowed bool
+	ShadowAllowed bool
+	ActiveReason  string
+	ShadowReason  string
+	Timestamp     time.Time
+}
+
+// ShadowEvaluator runs a candidate enforcement config against a sampled
+// fraction of live traffic without affecting real decisions: the active
+// enforcer's result is always what callers get, while the shadow's result
+// is only compared and any disagreement recorded. Operators read the
+// divergence report before promoting the candidate config.
+type ShadowEvaluator struct {
+	active   Enforcer
+	shadow   Enforcer
+	fraction float64 // sampled share of traffic in [0, 1]
+
+	mu          sync.Mutex
+	divergences []Divergence
+	sampled     uint64
+}
+
+func NewShadowEvaluator(active, shadow Enforcer, fraction float64) *ShadowEvaluator {
+	if fraction < 0 {
+		fraction = 0
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+	return &ShadowEvaluator{active: active, shadow: shadow, fraction: fraction}
+}
+
+// EnforceTransaction returns the active enforcer's decision. For sampled
+// transactions the shadow enforcer also runs; a shadow error is treated as
+// a rejection for comparison purposes and never surfaces to the caller.
+func (se *ShadowEvaluator) EnforceTransaction(ctx context.Context, tx Transaction) (EnforcementResult, error) {
+	result, err := se.active.EnforceTransaction(ctx, tx)
+	if err != nil || randFloat64() >= se.fraction {
+		return result, err
+	}
+
+	se.mu.Lock()
+	se.sampled++
+	se.mu.Unlock()
+
+	shadowResult, shadowErr := se.shadow.EnforceTransaction(ctx, tx)
+	if shadowErr != nil {
+		shadowResult = EnforcementResult{Allowed: false, Reason: shadowErr.Error()}
+	}
+	if shadowResult.Allowed != result.Allowed {
+		se.mu.Lock()
+		se.divergences = append(se.divergences, Divergence{
+			TransactionID: tx.ID,
+			ActiveAllowed: result.Allowed,
+			ShadowAllowed: shadowResult.Allowed,
+			ActiveReason:  result.Reason,
+			ShadowReason:  shadowResult.Reason,
+			Timestamp:     time.Now(),
+		})
+		se.mu.Unlock()
+	}
+	return result, nil
+}
+
+// Divergences returns a copy of the recorded disagreements.
+func (se *ShadowEvaluator) Divergences() []Divergence {
+	se.mu.Lock()
+	defer se.mu.Unlock()
+	out := make([]Divergence, len(se.divergences))
+	copy(out, se.divergences)
+	return out
+}
+
+// Sampled reports how many transactions the shadow config has evaluated.
+func (se *ShadowEvaluator) Sampled() uint64 {
+	se.mu.Lock()
+	defer se.mu.Unlock()
+	return se.sampled
+}
@@ -0,0 +1,178 @@
+package stablecoin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// LoopFunc is one supervised background loop. It should call tick() every
+// iteration so the supervisor can tell a live loop from a wedged one, and
+// return when ctx is done. Panics are recovered and counted; the loop is
+// restarted with backoff.
+type LoopFunc func(ctx context.Context, tick func())
+
+// LoopHealth is one loop's slice of the aggregate health report.
+type LoopHealth struct {
+	Name     string    `json:"name"`
+	Running  bool      `json:"running"`
+	LastTick time.Time `json:"lastTick"`
+	Restarts int       `json:"restarts"`
+	Panics   int       `json:"panics"`
+}
+
+type supervisedLoop struct {
+	name     string
+	fn       LoopFunc
+	running  bool
+	lastTick time.Time
+	restarts int
+	panics   int
+}
+
+// Supervisor keeps the process's background loops (self-optimizers, WAL
+// compaction, metric pushers) under one roof: it restarts crashed loops
+// with doubling backoff and aggregates their liveness for /healthz.
+type Supervisor struct {
+	staleAfter  time.Duration
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu    sync.Mutex
+	loops map[string]*supervisedLoop
+	wg    sync.WaitGroup
+}
+
+// NewSupervisor builds a supervisor that marks a loop unhealthy when it has
+// not ticked within staleAfter.
+func NewSupervisor(staleAfter time.Duration) *Supervisor {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Supervisor{
+		staleAfter:  staleAfter,
+		baseBackoff: time.Second,
+		maxBackoff:  time.Minute,
+		ctx:         ctx,
+		cancel:      cancel,
+		loops:       make(map[string]*supervisedLoop),
+	}
+}
+
+// Register starts the loop under supervision. Names must be unique.
+func (sv *Supervisor) Register(name string, fn LoopFunc) error {
+	sv.mu.Lock()
+	if _, exists := sv.loops[name]; exists {
+		sv.mu.Unlock()
+		return fmt.Errorf("loop %q already registered", name)
+	}
+	loop := &supervisedLoop{name: name, fn: fn, lastTick: time.Now()}
+	sv.loops[name] = loop
+	sv.mu.Unlock()
+
+	sv.wg.Add(1)
+	go sv.run(loop)
+	return nil
+}
+
+// run keeps one loop alive: each crash or panic is recorded and the loop is
+// restarted after a doubling backoff until the supervisor stops.
+func (sv *Supervisor) run(loop *supervisedLoop) {
+	defer sv.wg.Done()
+	backoff := sv.baseBackoff
+	tick := func() {
+		sv.mu.Lock()
+		loop.lastTick = time.Now()
+		sv.mu.Unlock()
+	}
+
+	for sv.ctx.Err() == nil {
+		sv.mu.Lock()
+		loop.running = true
+		sv.mu.Unlock()
+
+		panicked := sv.runOnce(loop, tick)
+
+		sv.mu.Lock()
+		loop.running = false
+		if panicked {
+			loop.panics++
+		}
+		sv.mu.Unlock()
+
+		if sv.ctx.Err() != nil {
+			return
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-sv.ctx.Done():
+			return
+		}
+		if backoff *= 2; backoff > sv.maxBackoff {
+			backoff = sv.maxBackoff
+		}
+		sv.mu.Lock()
+		loop.restarts++
+		sv.mu.Unlock()
+	}
+}
+
+// runOnce executes one incarnation of the loop, reporting whether it ended
+// in a panic.
+func (sv *Supervisor) runOnce(loop *supervisedLoop, tick func()) (panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicked = true
+			debugf("supervised loop %q panicked: %v", loop.name, r)
+		}
+	}()
+	loop.fn(sv.ctx, tick)
+	return false
+}
+
+// Stop shuts every loop down and waits for them to exit.
+func (sv *Supervisor) Stop() {
+	sv.cancel()
+	sv.wg.Wait()
+}
+
+// Health reports every loop's status. A loop is healthy when it is running
+// and has ticked within the staleness window.
+func (sv *Supervisor) Health() ([]LoopHealth, bool) {
+	sv.mu.Lock()
+	defer sv.mu.Unlock()
+	healthy := true
+	out := make([]LoopHealth, 0, len(sv.loops))
+	for _, loop := range sv.loops {
+		h := LoopHealth{
+			Name:     loop.name,
+			Running:  loop.running,
+			LastTick: loop.lastTick,
+			Restarts: loop.restarts,
+			Panics:   loop.panics,
+		}
+		if !h.Running || (sv.staleAfter > 0 && time.Since(h.LastTick) > sv.staleAfter) {
+			healthy = false
+		}
+		out = append(out, h)
+	}
+	return out, healthy
+}
+
+// HealthzHandler serves GET /healthz: 200 with the per-loop report when
+// every loop is live, 503 otherwise.
+func (sv *Supervisor) HealthzHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report, healthy := sv.Health()
+		w.Header().Set("Content-Type", "application/json")
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(report)
+	}
+}
@@ -0,0 +1,125 @@
+package stablecoin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSupervisorRestartsCrashedLoop(t *testing.T) {
+	sv := NewSupervisor(time.Second)
+	sv.baseBackoff = 2 * time.Millisecond
+	sv.maxBackoff = 10 * time.Millisecond
+	defer sv.Stop()
+
+	// The first incarnation dies immediately; replacements tick until
+	// shutdown.
+	var incarnations int32
+	if err := sv.Register("flaky", func(ctx context.Context, tick func()) {
+		if atomic.AddInt32(&incarnations, 1) == 1 {
+			return
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Millisecond):
+				tick()
+			}
+		}
+	}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		report, healthy := sv.Health()
+		if healthy && len(report) == 1 && report[0].Restarts >= 1 {
+			if report[0].Name != "flaky" || !report[0].Running {
+				t.Fatalf("health report = %+v", report[0])
+			}
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	report, _ := sv.Health()
+	t.Fatalf("loop never came back healthy after crashing: %+v", report)
+}
+
+func TestSupervisorCountsPanics(t *testing.T) {
+	sv := NewSupervisor(time.Second)
+	sv.baseBackoff = 2 * time.Millisecond
+	sv.maxBackoff = 10 * time.Millisecond
+	defer sv.Stop()
+
+	if err := sv.Register("panicky", func(ctx context.Context, tick func()) {
+		panic("loop bug")
+	}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		report, _ := sv.Health()
+		if len(report) == 1 && report[0].Panics >= 2 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	report, _ := sv.Health()
+	t.Fatalf("panics were not counted across restarts: %+v", report)
+}
+
+func TestHealthzReflectsLoopState(t *testing.T) {
+	sv := NewSupervisor(time.Second)
+	// A dead loop with a long backoff keeps the supervisor unhealthy for
+	// the duration of the test.
+	sv.baseBackoff = time.Hour
+	sv.maxBackoff = time.Hour
+	defer sv.Stop()
+
+	ticking := make(chan struct{})
+	sv.Register("steady", func(ctx context.Context, tick func()) {
+		tick()
+		close(ticking)
+		<-ctx.Done()
+	})
+	<-ticking
+
+	handler := sv.HealthzHandler()
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("/healthz with a live loop = %d, want 200", rec.Code)
+	}
+
+	exited := make(chan struct{})
+	sv.Register("dead", func(ctx context.Context, tick func()) {
+		defer close(exited)
+	})
+	<-exited
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, healthy := sv.Health(); !healthy {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("supervisor stayed healthy with a dead loop")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	rec = httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("/healthz with a dead loop = %d, want 503", rec.Code)
+	}
+	var report []LoopHealth
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil || len(report) != 2 {
+		t.Fatalf("healthz body = %q, %v; want the two-loop report", rec.Body.String(), err)
+	}
+}
@@ -0,0 +1,43 @@
+package stablecoin
+
+import "sync"
+
+// Tag keys are allow-listed to keep metrics-label cardinality bounded: an
+// arbitrary key set would let callers mint unbounded label combinations.
+var (
+	tagMu          sync.RWMutex
+	tagAllowedKeys = map[string]bool{}
+)
+
+// SetTagAllowedKeys replaces the set of tag keys accepted on transactions.
+// Tags with other keys are silently dropped during validation.
+func SetTagAllowedKeys(keys ...string) {
+	allowed := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		allowed[k] = true
+	}
+	tagMu.Lock()
+	tagAllowedKeys = allowed
+	tagMu.Unlock()
+}
+
+// filterTags keeps only allow-listed keys, returning nil when nothing
+// survives.
+func filterTags(tags map[string]string) map[string]string {
+	if len(tags) == 0 {
+		return nil
+	}
+	tagMu.RLock()
+	defer tagMu.RUnlock()
+	var out map[string]string
+	for k, v := range tags {
+		if !tagAllowedKeys[k] {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]string)
+		}
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,88 @@
+package stablecoin
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Tarpit progressively delays responses to origins that keep getting
+// rejected, to slow brute-force probing of the enforcement rules. The delay
+// doubles per recent rejection and is bounded by a cap; origins with no
+// recent rejections see no delay.
+type Tarpit struct {
+	base   time.Duration
+	max    time.Duration
+	window time.Duration
+
+	mu         sync.Mutex
+	rejections map[string][]time.Time
+}
+
+func NewTarpit(base, max, window time.Duration) *Tarpit {
+	return &Tarpit{
+		base:       base,
+		max:        max,
+		window:     window,
+		rejections: make(map[string][]time.Time),
+	}
+}
+
+// RecordRejection notes a rejection for origin.
+func (t *Tarpit) RecordRejection(origin string) {
+	now := time.Now()
+	t.mu.Lock()
+	t.rejections[origin] = append(t.prune(origin, now), now)
+	t.mu.Unlock()
+}
+
+// prune drops rejections older than the window. Callers hold t.mu.
+func (t *Tarpit) prune(origin string, now time.Time) []time.Time {
+	recent := t.rejections[origin][:0]
+	for _, ts := range t.rejections[origin] {
+		if now.Sub(ts) <= t.window {
+			recent = append(recent, ts)
+		}
+	}
+	t.rejections[origin] = recent
+	return recent
+}
+
+// Delay returns the current delay for origin: zero with no recent
+// rejections, then base doubling per rejection up to the cap.
+func (t *Tarpit) Delay(origin string) time.Duration {
+	t.mu.Lock()
+	n := len(t.prune(origin, time.Now()))
+	t.mu.Unlock()
+	if n == 0 {
+		return 0
+	}
+	d := t.base
+	for i := 1; i < n; i++ {
+		d *= 2
+		if d >= t.max {
+			return t.max
+		}
+	}
+	if d > t.max {
+		d = t.max
+	}
+	return d
+}
+
+// Wait sleeps for the origin's current delay, returning early if the
+// context is cancelled.
+func (t *Tarpit) Wait(ctx context.Context, origin string) error {
+	d := t.Delay(origin)
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
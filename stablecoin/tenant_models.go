@@ -0,0 +1,67 @@
+package stablecoin
+
+import (
+	"fmt"
+	"sync"
+)
+
+// TenantModelRegistry assigns each tenant its own trained model, loaded
+// lazily on first use and cached, with a shared default for tenants that
+// have no assignment.
+type TenantModelRegistry struct {
+	mu     sync.Mutex
+	load   ModelLoader
+	paths  map[string]string // tenant -> model path
+	loaded map[string]Model  // tenant -> cached model
+	shared Model
+}
+
+func NewTenantModelRegistry(load ModelLoader, shared Model) *TenantModelRegistry {
+	return &TenantModelRegistry{
+		load:   load,
+		paths:  make(map[string]string),
+		loaded: make(map[string]Model),
+		shared: shared,
+	}
+}
+
+// Assign maps a tenant to its model path. The model loads on the tenant's
+// next lookup; a previously cached model for the tenant is evicted.
+func (tr *TenantModelRegistry) Assign(tenant, path string) {
+	tr.mu.Lock()
+	tr.paths[tenant] = path
+	delete(tr.loaded, tenant)
+	tr.mu.Unlock()
+}
+
+// ModelFor returns the tenant's model, loading and caching it on first use.
+// Tenants without an assignment share the default model. A load failure is
+// returned rather than silently falling back, so a misconfigured tenant is
+// visible.
+func (tr *TenantModelRegistry) ModelFor(tenant string) (Model, error) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if m, ok := tr.loaded[tenant]; ok {
+		return m, nil
+	}
+	path, ok := tr.paths[tenant]
+	if !ok {
+		return tr.shared, nil
+	}
+	m, err := tr.load(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading model for tenant %s from %s: %v", tenant, path, err)
+	}
+	tr.loaded[tenant] = m
+	return m, nil
+}
+
+// CheckerFor builds a contamination checker on the tenant's model.
+func (tr *TenantModelRegistry) CheckerFor(tenant string) (*ContaminationChecker, error) {
+	m, err := tr.ModelFor(tenant)
+	if err != nil {
+		return nil, err
+	}
+	return NewContaminationChecker(m), nil
+}
@@ -0,0 +1,62 @@
+package stablecoin
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+)
+
+// Transaction is the unit of work flowing through enforcement, conversion
+// and the ledger.
+type Transaction struct {
+	ID           string
+	Origin       string
+	Recipient    string
+	Currency     string
+	Amount       float64
+	Jurisdiction string
+	Timestamp    int64 // Unix seconds
+	// ValidUntil, when non-zero, is the Unix second after which the
+	// transaction is no longer accepted.
+	ValidUntil int64
+
+	// Tags carry operator-supplied routing/analytics metadata. Keys are
+	// restricted to the configured allow-list (see SetTagAllowedKeys) and
+	// excluded from the canonical serialization: tags are annotations, not
+	// part of the transaction's identity.
+	Tags map[string]string
+}
+
+// CanonicalBytes returns a deterministic, length-prefixed serialization of
+// the transaction. Every field is emitted as a 4-byte big-endian length
+// followed by the field bytes, in a fixed order, so distinct transactions can
+// never serialize to the same byte string the way ad-hoc concatenation
+// (tx+origin+recipient) allows.
+func (t Transaction) CanonicalBytes() []byte {
+	fields := []string{
+		t.ID,
+		t.Origin,
+		t.Recipient,
+		t.Currency,
+		strconv.FormatFloat(t.Amount, 'g', -1, 64),
+		t.Jurisdiction,
+		strconv.FormatInt(t.Timestamp, 10),
+		strconv.FormatInt(t.ValidUntil, 10),
+	}
+	var buf []byte
+	var n [4]byte
+	for _, f := range fields {
+		binary.BigEndian.PutUint32(n[:], uint32(len(f)))
+		buf = append(buf, n[:]...)
+		buf = append(buf, f...)
+	}
+	return buf
+}
+
+// CanonicalHash is the SHA-256 of CanonicalBytes, hex encoded. All hashing
+// and signing of transactions goes through this single definition.
+func (t Transaction) CanonicalHash() string {
+	sum := sha256.Sum256(t.CanonicalBytes())
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,138 @@
+package stablecoin
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Protobuf wire-format encoding for Transaction and EnforcementResult, so
+// high-performance clients can bypass the string/GraphQL APIs. Field numbers
+// follow the message layout:
+//
+//	message Transaction {
+//	  string id = 1;
+//	  string origin = 2;
+//	  string recipient = 3;
+//	  string currency = 4;
+//	  double amount = 5;
+//	  string jurisdiction = 6;
+//	  int64 timestamp = 7;
+//	  int64 valid_until = 8;
+//	}
+//
+//	message EnforcementResult {
+//	  bool allowed = 1;
+//	  string reason = 2;
+//	}
+
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+)
+
+func appendTag(buf []byte, field, wire int) []byte {
+	return binary.AppendUvarint(buf, uint64(field)<<3|uint64(wire))
+}
+
+func appendString(buf []byte, field int, s string) []byte {
+	if s == "" {
+		return buf
+	}
+	buf = appendTag(buf, field, wireBytes)
+	buf = binary.AppendUvarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
+
+// MarshalProto encodes the transaction in protobuf wire format.
+func (t Transaction) MarshalProto() []byte {
+	var buf []byte
+	buf = appendString(buf, 1, t.ID)
+	buf = appendString(buf, 2, t.Origin)
+	buf = appendString(buf, 3, t.Recipient)
+	buf = appendString(buf, 4, t.Currency)
+	if t.Amount != 0 {
+		buf = appendTag(buf, 5, wireFixed64)
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(t.Amount))
+	}
+	buf = appendString(buf, 6, t.Jurisdiction)
+	if t.Timestamp != 0 {
+		buf = appendTag(buf, 7, wireVarint)
+		buf = binary.AppendUvarint(buf, uint64(t.Timestamp))
+	}
+	if t.ValidUntil != 0 {
+		buf = appendTag(buf, 8, wireVarint)
+		buf = binary.AppendUvarint(buf, uint64(t.ValidUntil))
+	}
+	return buf
+}
+
+// UnmarshalTransactionProto decodes a protobuf-encoded Transaction.
+func UnmarshalTransactionProto(data []byte) (Transaction, error) {
+	var tx Transaction
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return tx, fmt.Errorf("malformed tag")
+		}
+		data = data[n:]
+		field, wire := int(tag>>3), int(tag&7)
+
+		switch wire {
+		case wireBytes:
+			l, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data[n:])) < l {
+				return tx, fmt.Errorf("malformed length for field %d", field)
+			}
+			s := string(data[n : n+int(l)])
+			data = data[n+int(l):]
+			switch field {
+			case 1:
+				tx.ID = s
+			case 2:
+				tx.Origin = s
+			case 3:
+				tx.Recipient = s
+			case 4:
+				tx.Currency = s
+			case 6:
+				tx.Jurisdiction = s
+			}
+		case wireFixed64:
+			if len(data) < 8 {
+				return tx, fmt.Errorf("malformed fixed64 for field %d", field)
+			}
+			if field == 5 {
+				tx.Amount = math.Float64frombits(binary.LittleEndian.Uint64(data))
+			}
+			data = data[8:]
+		case wireVarint:
+			v, n := binary.Uvarint(data)
+			if n <= 0 {
+				return tx, fmt.Errorf("malformed varint for field %d", field)
+			}
+			switch field {
+			case 7:
+				tx.Timestamp = int64(v)
+			case 8:
+				tx.ValidUntil = int64(v)
+			}
+			data = data[n:]
+		default:
+			return tx, fmt.Errorf("unsupported wire type %d", wire)
+		}
+	}
+	return tx, nil
+}
+
+// MarshalProto encodes the result in protobuf wire format.
+func (r EnforcementResult) MarshalProto() []byte {
+	var buf []byte
+	if r.Allowed {
+		buf = appendTag(buf, 1, wireVarint)
+		buf = binary.AppendUvarint(buf, 1)
+	}
+	buf = appendString(buf, 2, r.Reason)
+	return buf
+}
@@ -0,0 +1,203 @@
+package stablecoin
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCanonicalHashSeparatesConcatenationCollisions(t *testing.T) {
+	// Under ad-hoc concatenation both of these render as "abc…": the field
+	// boundary is ambiguous. The length-prefixed canonical form keeps them
+	// distinct.
+	tx1 := Transaction{ID: "ab", Origin: "c", Recipient: "r", Currency: "USD", Amount: 1, Timestamp: 100}
+	tx2 := Transaction{ID: "a", Origin: "bc", Recipient: "r", Currency: "USD", Amount: 1, Timestamp: 100}
+	if tx1.ID+tx1.Origin != tx2.ID+tx2.Origin {
+		t.Fatal("test premise broken: transactions do not collide under concatenation")
+	}
+	if tx1.CanonicalHash() == tx2.CanonicalHash() {
+		t.Fatal("distinct transactions share a canonical hash")
+	}
+}
+
+func TestCanonicalHashIsDeterministic(t *testing.T) {
+	tx := Transaction{ID: "tx-1", Origin: "alice", Recipient: "bob", Currency: "USD", Amount: 3.14, Jurisdiction: "SEC", Timestamp: 1700000000}
+	if tx.CanonicalHash() != tx.CanonicalHash() {
+		t.Fatal("canonical hash is not deterministic")
+	}
+	// Tags are annotations, not identity: they must not affect the hash.
+	tagged := tx
+	tagged.Tags = map[string]string{"channel": "mobile"}
+	if tagged.CanonicalHash() != tx.CanonicalHash() {
+		t.Fatal("tags changed the canonical hash")
+	}
+}
+
+func TestTransactionProtoRoundTrip(t *testing.T) {
+	in := Transaction{
+		ID: "tx-7", Origin: "alice", Recipient: "bob", Currency: "USD",
+		Amount: 12.5, Jurisdiction: "SEC", Timestamp: 1700000000, ValidUntil: 1700000600,
+	}
+	out, err := UnmarshalTransactionProto(in.MarshalProto())
+	if err != nil {
+		t.Fatalf("UnmarshalTransactionProto: %v", err)
+	}
+	if !reflect.DeepEqual(out, in) {
+		t.Fatalf("round trip changed the transaction:\n in=%+v\nout=%+v", in, out)
+	}
+}
+
+func TestUnmarshalTransactionProtoRejectsTruncated(t *testing.T) {
+	data := Transaction{ID: "tx-7", Origin: "alice"}.MarshalProto()
+	if _, err := UnmarshalTransactionProto(data[:len(data)-2]); err == nil {
+		t.Fatal("truncated proto decoded without error")
+	}
+}
+
+func TestNormalizeAndValidateNormalizesFields(t *testing.T) {
+	// Decomposed "café" (e + combining acute) must normalize to the
+	// composed NFC form; padding is trimmed; currency is upper-cased
+	// without touching the casing of other fields.
+	tx, err := NormalizeAndValidate(Transaction{
+		ID:        "  tx-1  ",
+		Origin:    "cafe\u0301", // decomposed: e + combining acute
+		Recipient: "Bob",
+		Currency:  "usd",
+		Amount:    1,
+	})
+	if err != nil {
+		t.Fatalf("NormalizeAndValidate: %v", err)
+	}
+	if tx.ID != "tx-1" {
+		t.Fatalf("ID = %q, want trimmed %q", tx.ID, "tx-1")
+	}
+	if tx.Origin != "caf\u00e9" {
+		t.Fatalf("Origin = %q, want NFC-composed %q", tx.Origin, "caf\u00e9")
+	}
+	if tx.Recipient != "Bob" {
+		t.Fatalf("Recipient = %q, casing should be preserved", tx.Recipient)
+	}
+	if tx.Currency != "USD" {
+		t.Fatalf("Currency = %q, want %q", tx.Currency, "USD")
+	}
+	if tx.Timestamp == 0 {
+		t.Fatal("zero timestamp was not defaulted")
+	}
+}
+
+func TestNormalizeAndValidateMissingFields(t *testing.T) {
+	valid := Transaction{ID: "tx-1", Origin: "alice", Recipient: "bob", Currency: "USD", Amount: 1}
+	cases := []struct {
+		name   string
+		mutate func(*Transaction)
+	}{
+		{"id", func(tx *Transaction) { tx.ID = "" }},
+		{"origin", func(tx *Transaction) { tx.Origin = "  " }},
+		{"recipient", func(tx *Transaction) { tx.Recipient = "" }},
+		{"currency", func(tx *Transaction) { tx.Currency = "" }},
+	}
+	for _, tc := range cases {
+		tx := valid
+		tc.mutate(&tx)
+		_, err := NormalizeAndValidate(tx)
+		if !errors.Is(err, ErrMissingField) {
+			t.Errorf("%s: err = %v, want ErrMissingField", tc.name, err)
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.name) {
+			t.Errorf("%s: error %v does not name the field", tc.name, err)
+		}
+	}
+}
+
+func TestNormalizeAndValidateAmountAndExpiry(t *testing.T) {
+	base := Transaction{ID: "tx-1", Origin: "alice", Recipient: "bob", Currency: "USD", Timestamp: 1000}
+	for _, amount := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		tx := base
+		tx.Amount = amount
+		if _, err := NormalizeAndValidate(tx); err == nil {
+			t.Errorf("amount %g accepted", amount)
+		}
+	}
+	expired := base
+	expired.Amount = 1
+	expired.ValidUntil = 999
+	if _, err := NormalizeAndValidate(expired); err == nil {
+		t.Fatal("validUntil before timestamp accepted")
+	}
+}
+
+func TestFilterTagsDropsDisallowedKeys(t *testing.T) {
+	SetTagAllowedKeys("channel", "campaign")
+	defer SetTagAllowedKeys()
+
+	tx, err := NormalizeAndValidate(Transaction{
+		ID: "tx-1", Origin: "alice", Recipient: "bob", Currency: "USD", Amount: 1,
+		Tags: map[string]string{"channel": "mobile", "user_agent": "curl/8"},
+	})
+	if err != nil {
+		t.Fatalf("NormalizeAndValidate: %v", err)
+	}
+	if tx.Tags["channel"] != "mobile" {
+		t.Fatalf("allow-listed tag dropped: %v", tx.Tags)
+	}
+	if _, ok := tx.Tags["user_agent"]; ok {
+		t.Fatalf("disallowed tag kept: %v", tx.Tags)
+	}
+}
+
+func TestFeaturesFormatIsPinned(t *testing.T) {
+	tx := Transaction{
+		ID: "tx-1", Origin: "alice", Recipient: "bob", Currency: "USD",
+		Amount: 2.5, Jurisdiction: "SEC", Timestamp: 1700000000,
+	}
+	const want = "v1|id=tx-1|origin=alice|recipient=bob|currency=USD|amount=2.5|jurisdiction=SEC|timestamp=1700000000"
+	if got := Features(tx); got != want {
+		t.Fatalf("feature string changed:\n got %q\nwant %q\nbump FeatureFormatVersion if the change is intentional", got, want)
+	}
+}
+
+func TestCanonicalJurisdiction(t *testing.T) {
+	RegisterJurisdictionAlias("US", "SEC")
+	for _, code := range []string{"sec", "SEC", " Sec ", "us", "US"} {
+		if got := CanonicalJurisdiction(code); got != "SEC" {
+			t.Errorf("CanonicalJurisdiction(%q) = %q, want SEC", code, got)
+		}
+	}
+	if got := CanonicalJurisdiction("imf"); got != "IMF" {
+		t.Errorf("CanonicalJurisdiction(imf) = %q, want IMF", got)
+	}
+}
+
+func TestRedactTransactionAppliesPolicyModes(t *testing.T) {
+	SetRedactionPolicy(map[string]RedactionMode{
+		"id":        RedactHash,
+		"origin":    RedactMask,
+		"recipient": RedactDrop,
+	})
+	defer SetRedactionPolicy(nil)
+
+	tx := Transaction{ID: "tx-secret", Origin: "alice", Recipient: "bob", Currency: "USD"}
+	red := RedactTransaction(tx)
+	if !strings.HasPrefix(red.ID, "sha256:") || strings.Contains(red.ID, "secret") {
+		t.Fatalf("hashed field leaked or malformed: %q", red.ID)
+	}
+	if red.Origin != "al***" {
+		t.Fatalf("masked origin = %q, want %q", red.Origin, "al***")
+	}
+	if red.Recipient != "" {
+		t.Fatalf("dropped recipient = %q, want empty", red.Recipient)
+	}
+	if red.Currency != "USD" {
+		t.Fatalf("unpoliced field changed: %q", red.Currency)
+	}
+	// Redaction copies: the original keeps its values for enforcement.
+	if tx.ID != "tx-secret" || tx.Recipient != "bob" {
+		t.Fatalf("RedactTransaction mutated its input: %+v", tx)
+	}
+	// Same value, same hash: records stay join-able.
+	if RedactTransaction(tx).ID != red.ID {
+		t.Fatal("hash redaction is not stable across calls")
+	}
+}
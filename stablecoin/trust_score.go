@@ -0,0 +1,82 @@
+package stablecoin
+
+import (
+	"sync"
+	"time"
+)
+
+// TrustSignals are the per-identity factors combined into a trust score.
+type TrustSignals struct {
+	IdentityAge   time.Duration
+	RejectionRate float64 // fraction of this identity's past transactions rejected, 0..1
+	Jurisdiction  string
+}
+
+// TrustConfig tunes multi-factor trust scoring.
+type TrustConfig struct {
+	// MinScore is the score (0..1) an identity must reach to pass.
+	MinScore float64
+	// JurisdictionScores overrides the geo factor per jurisdiction;
+	// unlisted jurisdictions score 0.5.
+	JurisdictionScores map[string]float64
+	// MatureIdentityAge is the age at which the age factor saturates at
+	// 1.0. Defaults to one year.
+	MatureIdentityAge time.Duration
+}
+
+type trustScorer struct {
+	mu  sync.RWMutex
+	cfg TrustConfig
+}
+
+// SetTrustConfig installs the multi-factor trust configuration used by
+// TrustScore and Trusted.
+func (z *ZeroTrustValidator) SetTrustConfig(cfg TrustConfig) {
+	z.trust.mu.Lock()
+	z.trust.cfg = cfg
+	z.trust.mu.Unlock()
+}
+
+// TrustScore combines identity age, prior rejection rate and jurisdiction
+// into a score in [0, 1]. It is independent of the breach model: a
+// transaction can be clean yet come from an untrusted identity.
+func (z *ZeroTrustValidator) TrustScore(sig TrustSignals) float64 {
+	z.trust.mu.RLock()
+	cfg := z.trust.cfg
+	z.trust.mu.RUnlock()
+
+	mature := cfg.MatureIdentityAge
+	if mature <= 0 {
+		mature = 365 * 24 * time.Hour
+	}
+	ageScore := float64(sig.IdentityAge) / float64(mature)
+	if ageScore > 1 {
+		ageScore = 1
+	}
+	if ageScore < 0 {
+		ageScore = 0
+	}
+
+	rejectionScore := 1 - sig.RejectionRate
+	if rejectionScore < 0 {
+		rejectionScore = 0
+	}
+	if rejectionScore > 1 {
+		rejectionScore = 1
+	}
+
+	geoScore := 0.5
+	if s, ok := cfg.JurisdictionScores[sig.Jurisdiction]; ok {
+		geoScore = s
+	}
+
+	return (ageScore + rejectionScore + geoScore) / 3
+}
+
+// Trusted reports whether the signals reach the configured minimum score.
+func (z *ZeroTrustValidator) Trusted(sig TrustSignals) bool {
+	z.trust.mu.RLock()
+	min := z.trust.cfg.MinScore
+	z.trust.mu.RUnlock()
+	return z.TrustScore(sig) >= min
+}
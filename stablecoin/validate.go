@@ -0,0 +1,58 @@
+package stablecoin
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// ErrMissingField is returned when a required transaction field is empty;
+// the wrapped message names the offending field.
+var ErrMissingField = errors.New("missing required transaction field")
+
+// cleanField trims and NFC-normalizes a field without case-folding, so the
+// stored value keeps its original casing.
+func cleanField(s string) string {
+	return norm.NFC.String(strings.TrimSpace(s))
+}
+
+// NormalizeAndValidate is the single validation pre-stage shared by every
+// ingress (GraphQL, gRPC, CLI, direct calls). It normalizes fields, enforces
+// required ones, and applies basic sanity checks, so entry points cannot
+// drift apart in what they accept.
+func NormalizeAndValidate(raw Transaction) (Transaction, error) {
+	tx := raw
+	tx.ID = cleanField(tx.ID)
+	tx.Origin = cleanField(tx.Origin)
+	tx.Recipient = cleanField(tx.Recipient)
+	tx.Currency = strings.ToUpper(cleanField(tx.Currency))
+	tx.Jurisdiction = cleanField(tx.Jurisdiction)
+	tx.Tags = filterTags(tx.Tags)
+
+	if tx.ID == "" {
+		return Transaction{}, fmt.Errorf("%w: id", ErrMissingField)
+	}
+	if tx.Origin == "" {
+		return Transaction{}, fmt.Errorf("%w: origin", ErrMissingField)
+	}
+	if tx.Recipient == "" {
+		return Transaction{}, fmt.Errorf("%w: recipient", ErrMissingField)
+	}
+	if tx.Currency == "" {
+		return Transaction{}, fmt.Errorf("%w: currency", ErrMissingField)
+	}
+	if math.IsNaN(tx.Amount) || math.IsInf(tx.Amount, 0) || tx.Amount <= 0 {
+		return Transaction{}, fmt.Errorf("transaction amount must be a positive finite number, got %g", tx.Amount)
+	}
+	if tx.Timestamp == 0 {
+		tx.Timestamp = time.Now().Unix()
+	}
+	if tx.ValidUntil != 0 && tx.ValidUntil < tx.Timestamp {
+		return Transaction{}, fmt.Errorf("transaction validUntil %d precedes timestamp %d", tx.ValidUntil, tx.Timestamp)
+	}
+	return tx, nil
+}
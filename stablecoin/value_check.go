@@ -0,0 +1,47 @@
+package stablecoin
+
+import (
+	"fmt"
+	"math"
+	"sync"
+)
+
+// ValueCheck verifies that a transaction's amount sits on the configured
+// peg. Tolerance is per-origin: mining payouts can be required exact while
+// rewards may float by ±0.01, for example. Origins without an entry are
+// held to exact.
+type ValueCheck struct {
+	mu         sync.RWMutex
+	peg        float64
+	tolerances map[string]float64
+}
+
+func NewValueCheck(peg float64) *ValueCheck {
+	return &ValueCheck{
+		peg:        peg,
+		tolerances: make(map[string]float64),
+	}
+}
+
+// SetTolerance allows amounts from origin to deviate from the peg by up to
+// ±tol.
+func (vc *ValueCheck) SetTolerance(origin string, tol float64) {
+	vc.mu.Lock()
+	vc.tolerances[origin] = tol
+	vc.mu.Unlock()
+}
+
+// Check rejects a transaction whose amount deviates from the peg by more
+// than the origin's tolerance.
+func (vc *ValueCheck) Check(tx Transaction) error {
+	vc.mu.RLock()
+	tol := vc.tolerances[tx.Origin] // zero (exact) when unset
+	peg := vc.peg
+	vc.mu.RUnlock()
+
+	if diff := math.Abs(tx.Amount - peg); diff > tol {
+		return fmt.Errorf("amount %g deviates from peg %g by %g, beyond tolerance %g for origin %q",
+			tx.Amount, peg, diff, tol, tx.Origin)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package stablecoin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Build information, injected at link time:
+//
+//	go build -ldflags "-X .../stablecoin.Version=v1.2.3 \
+//	    -X .../stablecoin.GitCommit=$(git rev-parse HEAD) \
+//	    -X .../stablecoin.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	Version   = "dev"
+	GitCommit = "unknown"
+	BuildTime = "unknown"
+)
+
+// BuildInfo is the payload served by the /version endpoint.
+type BuildInfo struct {
+	Version      string   `json:"version"`
+	GitCommit    string   `json:"gitCommit"`
+	BuildTime    string   `json:"buildTime"`
+	ModelDigests []string `json:"modelDigests"`
+}
+
+var (
+	buildInfoMu  sync.Mutex
+	modelDigests []string
+)
+
+// RegisterModelDigest records a loaded model's verified digest for the
+// version endpoint.
+func RegisterModelDigest(digest string) {
+	if digest == "" {
+		return
+	}
+	buildInfoMu.Lock()
+	modelDigests = append(modelDigests, digest)
+	buildInfoMu.Unlock()
+}
+
+// CurrentBuildInfo assembles the running build's identity.
+func CurrentBuildInfo() BuildInfo {
+	buildInfoMu.Lock()
+	digests := make([]string, len(modelDigests))
+	copy(digests, modelDigests)
+	buildInfoMu.Unlock()
+	return BuildInfo{
+		Version:      Version,
+		GitCommit:    GitCommit,
+		BuildTime:    BuildTime,
+		ModelDigests: digests,
+	}
+}
+
+// VersionHandler serves GET /version.
+func VersionHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(CurrentBuildInfo())
+	}
+}
+
+// PrintVersion is the CLI `version` command.
+func PrintVersion(w io.Writer) {
+	info := CurrentBuildInfo()
+	fmt.Fprintf(w, "pi-supernode %s (commit %s, built %s)\n", info.Version, info.GitCommit, info.BuildTime)
+	for _, d := range info.ModelDigests {
+		fmt.Fprintf(w, "model digest: %s\n", d)
+	}
+}
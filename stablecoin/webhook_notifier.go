@@ -0,0 +1,185 @@
+package stablecoin
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// WebhookSignatureHeader carries the HMAC-SHA256 of the request body.
+//
+// Verification recipe for receivers:
+//
+//	expected = "sha256=" + hex(hmac_sha256(secret, body))
+//	compare expected against the header with a constant-time comparison;
+//	during rotation, accept a match against either the new or old secret.
+const WebhookSignatureHeader = "X-PiSupernode-Signature"
+
+// SignWebhookPayload computes the signature header value for a payload.
+func SignWebhookPayload(secret, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature checks the header against the payload under any of
+// the given secrets, supporting rotation windows where old and new secrets
+// overlap.
+func VerifyWebhookSignature(secrets [][]byte, payload []byte, header string) bool {
+	for _, secret := range secrets {
+		expected := SignWebhookPayload(secret, payload)
+		if hmac.Equal([]byte(expected), []byte(header)) {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryPolicy controls webhook delivery retries.
+type RetryPolicy struct {
+	MaxAttempts    int           // total attempts, including the first
+	BaseDelay      time.Duration // delay before the second attempt
+	BackoffFactor  float64       // multiplier applied per attempt
+	Jitter         time.Duration // random extra delay in [0, Jitter)
+	AttemptTimeout time.Duration // per-attempt request timeout
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		BaseDelay:      200 * time.Millisecond,
+		BackoffFactor:  2.0,
+		Jitter:         50 * time.Millisecond,
+		AttemptTimeout: 5 * time.Second,
+	}
+}
+
+// WebhookNotifier delivers JSON payloads to a webhook endpoint with retries.
+// Pending payloads sit in a bounded in-memory queue; when the queue is full
+// the oldest payload is dropped and the drop counter is incremented.
+type WebhookNotifier struct {
+	url    string
+	policy RetryPolicy
+	client *http.Client
+
+	mu       sync.Mutex
+	queue    [][]byte
+	maxQueue int
+	dropped  uint64
+	secret   []byte
+}
+
+// SetSecret installs the shared secret used to sign outgoing payloads.
+// Rotating is a matter of calling SetSecret with the new value; receivers
+// should accept both secrets during the rotation window.
+func (wn *WebhookNotifier) SetSecret(secret []byte) {
+	wn.mu.Lock()
+	wn.secret = secret
+	wn.mu.Unlock()
+}
+
+func NewWebhookNotifier(url string, maxQueue int, policy RetryPolicy) *WebhookNotifier {
+	if maxQueue <= 0 {
+		maxQueue = 1024
+	}
+	return &WebhookNotifier{
+		url:      url,
+		policy:   policy,
+		client:   &http.Client{},
+		maxQueue: maxQueue,
+	}
+}
+
+// Enqueue adds a payload to the delivery queue, dropping the oldest entry on
+// overflow.
+func (wn *WebhookNotifier) Enqueue(payload []byte) {
+	wn.mu.Lock()
+	defer wn.mu.Unlock()
+	if len(wn.queue) >= wn.maxQueue {
+		wn.queue = wn.queue[1:]
+		atomic.AddUint64(&wn.dropped, 1)
+	}
+	wn.queue = append(wn.queue, payload)
+}
+
+// Dropped reports how many payloads have been discarded due to overflow.
+func (wn *WebhookNotifier) Dropped() uint64 {
+	return atomic.LoadUint64(&wn.dropped)
+}
+
+// Flush delivers every queued payload in order, applying the retry policy to
+// each. Payloads that exhaust their retries are dropped and counted.
+func (wn *WebhookNotifier) Flush(ctx context.Context) error {
+	for {
+		wn.mu.Lock()
+		if len(wn.queue) == 0 {
+			wn.mu.Unlock()
+			return nil
+		}
+		payload := wn.queue[0]
+		wn.queue = wn.queue[1:]
+		wn.mu.Unlock()
+
+		if err := wn.deliver(ctx, payload); err != nil {
+			atomic.AddUint64(&wn.dropped, 1)
+			return err
+		}
+	}
+}
+
+func (wn *WebhookNotifier) deliver(ctx context.Context, payload []byte) error {
+	var lastErr error
+	delay := wn.policy.BaseDelay
+	for attempt := 1; attempt <= wn.policy.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			sleep := delay
+			if wn.policy.Jitter > 0 {
+				sleep += time.Duration(randInt63n(int64(wn.policy.Jitter)))
+			}
+			select {
+			case <-time.After(sleep):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			delay = time.Duration(float64(delay) * wn.policy.BackoffFactor)
+		}
+
+		attemptCtx := ctx
+		if wn.policy.AttemptTimeout > 0 {
+			var cancel context.CancelFunc
+			attemptCtx, cancel = context.WithTimeout(ctx, wn.policy.AttemptTimeout)
+			defer cancel()
+		}
+
+		req, err := http.NewRequestWithContext(attemptCtx, http.MethodPost, wn.url, bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		wn.mu.Lock()
+		secret := wn.secret
+		wn.mu.Unlock()
+		if len(secret) > 0 {
+			req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(secret, payload))
+		}
+
+		resp, err := wn.client.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		lastErr = fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return fmt.Errorf("webhook delivery failed after %d attempts: %v", wn.policy.MaxAttempts, lastErr)
+}
@@ -0,0 +1,150 @@
+package stablecoin
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastRetryPolicy keeps retry tests quick without changing the semantics
+// under test.
+func fastRetryPolicy(maxAttempts int) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    maxAttempts,
+		BaseDelay:      time.Millisecond,
+		BackoffFactor:  1.0,
+		Jitter:         0,
+		AttemptTimeout: time.Second,
+	}
+}
+
+func TestFlushSucceedsOnThirdAttempt(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	wn := NewWebhookNotifier(srv.URL, 8, fastRetryPolicy(3))
+	wn.Enqueue([]byte(`{"event":"test"}`))
+	if err := wn.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush against flaky endpoint: %v", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Fatalf("endpoint saw %d attempts, want 3", got)
+	}
+	if wn.Dropped() != 0 {
+		t.Fatalf("successful delivery counted %d drops", wn.Dropped())
+	}
+}
+
+func TestFlushExhaustsRetriesAndCountsDrop(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	wn := NewWebhookNotifier(srv.URL, 8, fastRetryPolicy(3))
+	wn.Enqueue([]byte(`{"event":"test"}`))
+	if err := wn.Flush(context.Background()); err == nil {
+		t.Fatal("Flush succeeded against an always-failing endpoint")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Fatalf("endpoint saw %d attempts, want 3", got)
+	}
+	if wn.Dropped() != 1 {
+		t.Fatalf("Dropped() = %d, want 1", wn.Dropped())
+	}
+}
+
+func TestEnqueueDropsOldestOnOverflow(t *testing.T) {
+	wn := NewWebhookNotifier("http://unused.invalid", 2, DefaultRetryPolicy())
+	wn.Enqueue([]byte("a"))
+	wn.Enqueue([]byte("b"))
+	wn.Enqueue([]byte("c"))
+	if wn.Dropped() != 1 {
+		t.Fatalf("Dropped() = %d, want 1", wn.Dropped())
+	}
+	wn.mu.Lock()
+	defer wn.mu.Unlock()
+	if len(wn.queue) != 2 || string(wn.queue[0]) != "b" || string(wn.queue[1]) != "c" {
+		t.Fatalf("queue after overflow = %q, want [b c]", wn.queue)
+	}
+}
+
+func TestWebhookSignatureVerifiesAndDetectsTamper(t *testing.T) {
+	secret := []byte("shared-secret")
+	payload := []byte(`{"event":"monitored_transaction"}`)
+
+	var gotHeader string
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get(WebhookSignatureHeader)
+		body := make([]byte, r.ContentLength)
+		r.Body.Read(body)
+		gotBody = body
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	wn := NewWebhookNotifier(srv.URL, 8, fastRetryPolicy(1))
+	wn.SetSecret(secret)
+	wn.Enqueue(payload)
+	if err := wn.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	if !VerifyWebhookSignature([][]byte{secret}, gotBody, gotHeader) {
+		t.Fatalf("signature %q does not verify against delivered body", gotHeader)
+	}
+	tampered := append([]byte{}, gotBody...)
+	tampered[len(tampered)-2] = 'X'
+	if VerifyWebhookSignature([][]byte{secret}, tampered, gotHeader) {
+		t.Fatal("signature verified against a tampered body")
+	}
+	// Rotation window: the old secret still verifies alongside the new one.
+	if !VerifyWebhookSignature([][]byte{[]byte("new-secret"), secret}, gotBody, gotHeader) {
+		t.Fatal("signature rejected during rotation window with both secrets")
+	}
+}
+
+func TestSeededRandSourceIsReproducible(t *testing.T) {
+	draw := func(seed int64) ([]int64, []float64) {
+		SetRandSource(rand.NewSource(seed))
+		ints := make([]int64, 5)
+		floats := make([]float64, 5)
+		for i := range ints {
+			ints[i] = randInt63n(1000)
+			floats[i] = randFloat64()
+		}
+		return ints, floats
+	}
+	ints1, floats1 := draw(42)
+	ints2, floats2 := draw(42)
+	for i := range ints1 {
+		if ints1[i] != ints2[i] || floats1[i] != floats2[i] {
+			t.Fatalf("seeded draws diverge at %d: (%d,%g) vs (%d,%g)",
+				i, ints1[i], floats1[i], ints2[i], floats2[i])
+		}
+	}
+	ints3, _ := draw(43)
+	same := true
+	for i := range ints1 {
+		if ints1[i] != ints3[i] {
+			same = false
+		}
+	}
+	if same {
+		t.Fatal("different seeds produced identical draws")
+	}
+}
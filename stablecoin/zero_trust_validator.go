@@ -0,0 +1,109 @@
+package stablecoin
+
+import (
+	"container/list"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// ZeroTrustValidator verifies identity proofs. Verification results are
+// held in a size-bounded LRU cache with TTL so repeated identities skip the
+// hash recomputation.
+type ZeroTrustValidator struct {
+	mu         sync.Mutex
+	key        []byte
+	maxEntries int
+	ttl        time.Duration
+	lru        *list.List // front = most recent
+	cache      map[string]*list.Element
+
+	trust trustScorer
+}
+
+type identityCacheEntry struct {
+	cacheKey string
+	verified bool
+	at       time.Time
+}
+
+func NewZeroTrustValidator(key []byte, maxEntries int, ttl time.Duration) *ZeroTrustValidator {
+	if maxEntries <= 0 {
+		maxEntries = 1024
+	}
+	return &ZeroTrustValidator{
+		key:        key,
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		lru:        list.New(),
+		cache:      make(map[string]*list.Element),
+		trust:      trustScorer{cfg: TrustConfig{MinScore: 0.5}},
+	}
+}
+
+// quantumVerifyIdentity recomputes the keyed identity hash and compares it
+// to the supplied proof.
+func (z *ZeroTrustValidator) quantumVerifyIdentity(identity, proof string) bool {
+	mac := hmac.New(sha256.New, z.key)
+	mac.Write([]byte(identity))
+	expected := hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(proof))
+}
+
+// VerifyIdentity checks the proof for an identity, serving repeat requests
+// from the cache until the TTL lapses.
+func (z *ZeroTrustValidator) VerifyIdentity(identity, proof string) bool {
+	cacheKey := identity + "\x00" + proof
+
+	z.mu.Lock()
+	if el, ok := z.cache[cacheKey]; ok {
+		entry := el.Value.(*identityCacheEntry)
+		if z.ttl <= 0 || time.Since(entry.at) < z.ttl {
+			z.lru.MoveToFront(el)
+			verified := entry.verified
+			z.mu.Unlock()
+			return verified
+		}
+		z.lru.Remove(el)
+		delete(z.cache, cacheKey)
+	}
+	z.mu.Unlock()
+
+	verified := z.quantumVerifyIdentity(identity, proof)
+
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	if _, ok := z.cache[cacheKey]; !ok {
+		el := z.lru.PushFront(&identityCacheEntry{
+			cacheKey: cacheKey,
+			verified: verified,
+			at:       time.Now(),
+		})
+		z.cache[cacheKey] = el
+		for z.lru.Len() > z.maxEntries {
+			oldest := z.lru.Back()
+			z.lru.Remove(oldest)
+			delete(z.cache, oldest.Value.(*identityCacheEntry).cacheKey)
+		}
+	}
+	return verified
+}
+
+// RotateKey installs a new verification key and invalidates every cached
+// result, since proofs under the old key are no longer valid.
+func (z *ZeroTrustValidator) RotateKey(newKey []byte) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	z.key = newKey
+	z.lru.Init()
+	z.cache = make(map[string]*list.Element)
+}
+
+// CacheLen reports the number of cached verification results.
+func (z *ZeroTrustValidator) CacheLen() int {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	return z.lru.Len()
+}